@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"sync"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/gke"
+	"github.com/rancher/gke-operator/pkg/gke/services"
+	wranglerv1 "github.com/rancher/wrangler/v3/pkg/generated/controllers/core/v1"
+)
+
+// gkeClientCache caches a GKE client per credential secret, so the token source and the container
+// API client are only built again when the secret changes instead of on every reconcile. It is
+// safe for concurrent use by the controller workers.
+type gkeClientCache struct {
+	mu        sync.Mutex
+	clients   map[string]cachedGKEClient
+	newClient func(ctx context.Context, credential string) (services.GKEClusterService, error)
+}
+
+type cachedGKEClient struct {
+	resourceVersion string
+	client          services.GKEClusterService
+}
+
+func newGKEClientCache() *gkeClientCache {
+	return &gkeClientCache{
+		clients:   map[string]cachedGKEClient{},
+		newClient: gke.GetGKEClusterClient,
+	}
+}
+
+// get returns the client for the credential secret referenced by the config spec. A new client is
+// built if none is cached for the secret or the secret changed since the cached one was built. The
+// secret is read from the informer cache, which OnCredentialSecretChanged keeps watching, so the API
+// server is not queried on every reconcile.
+func (c *gkeClientCache) get(secretsCache wranglerv1.SecretCache, configSpec *gkev1.GKEClusterConfigSpec) (services.GKEClusterService, error) {
+	ns, name := parseCredential(configSpec.GoogleCredentialSecret)
+	secret, err := secretsCache.Get(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	key := secretKey(secret.Namespace, secret.Name)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if cached, ok := c.clients[key]; ok && cached.resourceVersion == secret.ResourceVersion {
+		return cached.client, nil
+	}
+	delete(c.clients, key)
+
+	cred, err := credentialFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+	// the client outlives the reconcile that builds it, so it must not use the reconcile's
+	// context, which is cancelled once the reconcile returns
+	client, err := c.newClient(context.Background(), cred)
+	if err != nil {
+		return nil, err
+	}
+	c.clients[key] = cachedGKEClient{
+		resourceVersion: secret.ResourceVersion,
+		client:          client,
+	}
+	return client, nil
+}
+
+// invalidate removes the cached client for the secret with the given namespace/name key.
+func (c *gkeClientCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.clients, key)
+}
+
+func secretKey(namespace, name string) string {
+	return namespace + "/" + name
+}
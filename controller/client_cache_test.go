@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/gke/services"
+	"github.com/rancher/gke-operator/pkg/gke/services/mock_services"
+	"github.com/rancher/gke-operator/pkg/test"
+	wranglerv1 "github.com/rancher/wrangler/v3/pkg/generated/controllers/core/v1"
+)
+
+var _ = Describe("gkeClientCache", func() {
+	var (
+		mockController   *gomock.Controller
+		clientCache      *gkeClientCache
+		secretsCache     wranglerv1.SecretCache
+		secretsIndexer   cache.Indexer
+		credentialSecret *corev1.Secret
+		configSpec       *gkev1.GKEClusterConfigSpec
+		built            []string
+		buildErr         error
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		built = nil
+		buildErr = nil
+
+		credentialSecret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cache-test-secret",
+				Namespace: "default",
+			},
+			Data: map[string][]byte{
+				"googlecredentialConfig-authEncodedJson": []byte("credential-1"),
+			},
+		}
+		Expect(cl.Create(ctx, credentialSecret)).To(Succeed())
+
+		// the informer is not started in the tests, the secret is added to its store instead
+		secretsCache = coreFactory.Core().V1().Secret().Cache()
+		secretsIndexer = coreFactory.Core().V1().Secret().Informer().GetIndexer()
+		Expect(secretsIndexer.Add(credentialSecret.DeepCopy())).To(Succeed())
+
+		configSpec = &gkev1.GKEClusterConfigSpec{
+			GoogleCredentialSecret: credentialSecret.Namespace + ":" + credentialSecret.Name,
+		}
+
+		clientCache = newGKEClientCache()
+		clientCache.newClient = func(_ context.Context, credential string) (services.GKEClusterService, error) {
+			if buildErr != nil {
+				return nil, buildErr
+			}
+			built = append(built, credential)
+			return mock_services.NewMockGKEClusterService(mockController), nil
+		}
+	})
+
+	AfterEach(func() {
+		Expect(secretsIndexer.Delete(credentialSecret)).To(Succeed())
+		Expect(test.CleanupAndWait(ctx, cl, credentialSecret)).To(Succeed())
+		mockController.Finish()
+	})
+
+	It("should reuse the client while the secret is unchanged", func() {
+		gkeClient, err := clientCache.get(secretsCache, configSpec)
+		Expect(err).ToNot(HaveOccurred())
+
+		cachedClient, err := clientCache.get(secretsCache, configSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cachedClient).To(BeIdenticalTo(gkeClient))
+		Expect(built).To(Equal([]string{"credential-1"}))
+	})
+
+	It("should read the secret from the informer cache", func() {
+		cachedSecret := credentialSecret.DeepCopy()
+		cachedSecret.Data["googlecredentialConfig-authEncodedJson"] = []byte("cached-credential")
+		Expect(secretsIndexer.Update(cachedSecret)).To(Succeed())
+
+		_, err := clientCache.get(secretsCache, configSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(built).To(Equal([]string{"cached-credential"}))
+	})
+
+	It("should build a new client when the secret changes", func() {
+		gkeClient, err := clientCache.get(secretsCache, configSpec)
+		Expect(err).ToNot(HaveOccurred())
+
+		credentialSecret.Data["googlecredentialConfig-authEncodedJson"] = []byte("credential-2")
+		Expect(cl.Update(ctx, credentialSecret)).To(Succeed())
+		Expect(secretsIndexer.Update(credentialSecret.DeepCopy())).To(Succeed())
+
+		newClient, err := clientCache.get(secretsCache, configSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(newClient).NotTo(BeIdenticalTo(gkeClient))
+		Expect(built).To(Equal([]string{"credential-1", "credential-2"}))
+	})
+
+	It("should not cache a client that failed to build", func() {
+		buildErr = fmt.Errorf("invalid credential")
+		_, err := clientCache.get(secretsCache, configSpec)
+		Expect(err).To(MatchError("invalid credential"))
+
+		buildErr = nil
+		_, err = clientCache.get(secretsCache, configSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(built).To(Equal([]string{"credential-1"}))
+	})
+
+	It("should return an error for a malformed secret", func() {
+		delete(credentialSecret.Data, "googlecredentialConfig-authEncodedJson")
+		Expect(cl.Update(ctx, credentialSecret)).To(Succeed())
+		Expect(secretsIndexer.Update(credentialSecret.DeepCopy())).To(Succeed())
+
+		_, err := clientCache.get(secretsCache, configSpec)
+		Expect(err).To(MatchError("could not read malformed cloud credential secret cache-test-secret from namespace default"))
+		Expect(built).To(BeEmpty())
+	})
+
+	It("should be safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				_, err := clientCache.get(secretsCache, configSpec)
+				Expect(err).ToNot(HaveOccurred())
+			}()
+		}
+		wg.Wait()
+		Expect(built).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"fmt"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/wrangler/v3/pkg/condition"
+)
+
+// Condition reasons
+const (
+	reasonCreating              = "Creating"
+	reasonImporting             = "Importing"
+	reasonProvisioned           = "Provisioned"
+	reasonUpdated               = "Updated"
+	reasonError                 = "Error"
+	reasonDeleting              = "Deleting"
+	reasonClusterReconciling    = "ClusterReconciling"
+	reasonNodePoolBusy          = "NodePoolReconciling"
+	reasonShieldedNodesDisabled = "ShieldedNodesDisabled"
+	reasonShieldedNodesDrift    = "ShieldedNodesDrift"
+)
+
+// Update steps, used as the reason of the Updating condition so it is visible
+// which step of updateUpstreamClusterState is being waited on.
+const (
+	stepDatabaseEncryption       = "DatabaseEncryption"
+	stepReleaseChannel           = "ReleaseChannel"
+	stepKubernetesVersion        = "KubernetesVersion"
+	stepClusterAddons            = "ClusterAddons"
+	stepMasterAuthorizedNetworks = "MasterAuthorizedNetworks"
+	stepPrivateClusterConfig     = "PrivateClusterConfig"
+	stepControlPlaneEndpoints    = "ControlPlaneEndpoints"
+	stepLoggingMonitoringService = "LoggingMonitoringService"
+	stepNetworkPolicy            = "NetworkPolicy"
+	stepDNSConfig                = "DNSConfig"
+	stepStackType                = "StackType"
+	stepAdditionalPodRanges      = "AdditionalPodRanges"
+	stepLocations                = "Locations"
+	stepClusterAutoscaling       = "ClusterAutoscaling"
+	stepMaintenanceWindow        = "MaintenanceWindow"
+	stepMaintenancePolicy        = "MaintenancePolicy"
+	stepShieldedNodes            = "ShieldedNodes"
+	stepWorkloadIdentity         = "WorkloadIdentity"
+	stepLabels                   = "Labels"
+	stepNodePools                = "NodePools"
+	stepNodePoolReplacement      = "NodePoolReplacement"
+)
+
+func setCondition(config *gkev1.GKEClusterConfig, cond condition.Cond, status bool, reason, message string) {
+	if status {
+		cond.True(config)
+	} else {
+		cond.False(config)
+	}
+	cond.Reason(config, reason)
+	cond.Message(config, message)
+}
+
+// setProvisioningConditions marks the config as waiting for the upstream cluster to be created or imported.
+func setProvisioningConditions(config *gkev1.GKEClusterConfig, reason string) {
+	config.Status.ObservedGeneration = config.Generation
+	setCondition(config, gkev1.ClusterConditionProvisioning, true, reason, "")
+	setCondition(config, gkev1.ClusterConditionReady, false, reason, "")
+}
+
+// setUpdatingConditions marks the config as waiting for the given update step to complete upstream.
+func setUpdatingConditions(config *gkev1.GKEClusterConfig, reason, message string) {
+	setCondition(config, gkev1.ClusterConditionUpdating, true, reason, message)
+	setCondition(config, gkev1.ClusterConditionReady, false, reason, message)
+}
+
+// setActiveConditions marks the config as running with the latest generation of the spec reconciled.
+func setActiveConditions(config *gkev1.GKEClusterConfig) {
+	config.Status.ObservedGeneration = config.Generation
+	if gkev1.ClusterConditionProvisioning.IsTrue(config) {
+		setCondition(config, gkev1.ClusterConditionProvisioning, false, reasonProvisioned, "")
+	}
+	if gkev1.ClusterConditionUpdating.IsTrue(config) {
+		setCondition(config, gkev1.ClusterConditionUpdating, false, reasonUpdated, "")
+	}
+	setCondition(config, gkev1.ClusterConditionReady, true, "", "")
+}
+
+// setErrorConditions records the result of the last reconcile in the Degraded condition.
+func setErrorConditions(config *gkev1.GKEClusterConfig, message string) {
+	if message == "" {
+		if gkev1.ClusterConditionDegraded.IsTrue(config) {
+			setCondition(config, gkev1.ClusterConditionDegraded, false, "", "")
+		}
+		return
+	}
+	setCondition(config, gkev1.ClusterConditionDegraded, true, reasonError, message)
+	setCondition(config, gkev1.ClusterConditionReady, false, reasonError, message)
+}
+
+// setCredentialsConditions records whether the Google credential secret could be used.
+func setCredentialsConditions(config *gkev1.GKEClusterConfig, err error) {
+	if err != nil {
+		setCondition(config, gkev1.ClusterConditionCredentialsValid, false, reasonError, err.Error())
+		return
+	}
+	setCondition(config, gkev1.ClusterConditionCredentialsValid, true, "", "")
+}
+
+// setDeletingConditions marks the config as waiting for the upstream cluster to be deleted.
+func setDeletingConditions(config *gkev1.GKEClusterConfig) {
+	setCondition(config, gkev1.ClusterConditionDeleting, true, reasonDeleting, "")
+	setCondition(config, gkev1.ClusterConditionReady, false, reasonDeleting, "")
+}
+
+// setShieldedNodesConditions raises the SecurityWarning condition while shielded nodes are disabled
+// upstream, or differ from a spec that was never applied to the cluster, and clears it otherwise.
+// The spec is recorded as applied once the cluster matches it. Returns true if the status changed.
+func setShieldedNodesConditions(config *gkev1.GKEClusterConfig, upstreamSpec *gkev1.GKEClusterConfigSpec) bool {
+	changed := false
+	spec, applied := config.Spec.ShieldedNodes, config.Status.AppliedShieldedNodes
+	upstreamEnabled := upstreamSpec.ShieldedNodes != nil && upstreamSpec.ShieldedNodes.Enabled
+	if spec != nil && spec.Enabled == upstreamEnabled && (applied == nil || *applied != *spec) {
+		config.Status.AppliedShieldedNodes = spec.DeepCopy()
+		changed = true
+	}
+
+	var reason, message string
+	switch {
+	case upstreamSpec.ShieldedNodes != nil && !upstreamEnabled:
+		reason = reasonShieldedNodesDisabled
+		message = "shielded nodes are disabled, nodes are no longer protected by secure boot and integrity monitoring"
+	case spec != nil && spec.Enabled != upstreamEnabled && (applied == nil || applied.Enabled == spec.Enabled):
+		reason = reasonShieldedNodesDrift
+		message = "shielded nodes are enabled on the cluster but disabled in the spec, which was not applied when the cluster was created, set shieldedNodes.enabled to true and then back to false to disable them"
+	default:
+		if gkev1.ClusterConditionSecurityWarning.IsTrue(config) {
+			setCondition(config, gkev1.ClusterConditionSecurityWarning, false, "", "")
+			changed = true
+		}
+		return changed
+	}
+	if gkev1.ClusterConditionSecurityWarning.IsTrue(config) &&
+		gkev1.ClusterConditionSecurityWarning.GetReason(config) == reason &&
+		gkev1.ClusterConditionSecurityWarning.GetMessage(config) == message {
+		return changed
+	}
+	setCondition(config, gkev1.ClusterConditionSecurityWarning, true, reason, message)
+	return true
+}
+
+// needsActiveConditions returns true if the config has not yet been marked as
+// ready for its current generation.
+func needsActiveConditions(config *gkev1.GKEClusterConfig) bool {
+	return config.Status.ObservedGeneration != config.Generation ||
+		!gkev1.ClusterConditionReady.IsTrue(config) ||
+		gkev1.ClusterConditionUpdating.IsTrue(config) ||
+		gkev1.ClusterConditionProvisioning.IsTrue(config)
+}
+
+// operationMessage returns the message of the Updating condition while the given operation is pending.
+func operationMessage(operation *gkev1.GKEOperation) string {
+	if operation == nil {
+		return ""
+	}
+	return fmt.Sprintf("waiting for operation %s [%s]", operation.Name, operation.OperationType)
+}
@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/test"
+)
+
+var _ = Describe("conditions", func() {
+	var gkeConfig *gkev1.GKEClusterConfig
+
+	BeforeEach(func() {
+		gkeConfig = &gkev1.GKEClusterConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test",
+				Namespace:  "default",
+				Generation: 1,
+			},
+		}
+	})
+
+	It("should set and then clear the Degraded condition", func() {
+		setErrorConditions(gkeConfig, "error updating cluster")
+		Expect(gkev1.ClusterConditionDegraded.IsTrue(gkeConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionDegraded.GetMessage(gkeConfig)).To(Equal("error updating cluster"))
+		Expect(gkev1.ClusterConditionReady.IsFalse(gkeConfig)).To(BeTrue())
+		Expect(gkeConfig.Status.ObservedGeneration).To(BeZero())
+
+		setErrorConditions(gkeConfig, "")
+		Expect(gkev1.ClusterConditionDegraded.IsFalse(gkeConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionDegraded.GetMessage(gkeConfig)).To(BeEmpty())
+	})
+
+	It("should set CredentialsValid from false to true", func() {
+		setCredentialsConditions(gkeConfig, fmt.Errorf("invalid credential"))
+		Expect(gkev1.ClusterConditionCredentialsValid.IsFalse(gkeConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionCredentialsValid.GetMessage(gkeConfig)).To(Equal("invalid credential"))
+
+		setCredentialsConditions(gkeConfig, nil)
+		Expect(gkev1.ClusterConditionCredentialsValid.IsTrue(gkeConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionCredentialsValid.GetMessage(gkeConfig)).To(BeEmpty())
+	})
+
+	It("should set the Deleting condition", func() {
+		setActiveConditions(gkeConfig)
+		setDeletingConditions(gkeConfig)
+		Expect(gkev1.ClusterConditionDeleting.IsTrue(gkeConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionReady.IsFalse(gkeConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionReady.GetReason(gkeConfig)).To(Equal(reasonDeleting))
+	})
+
+	It("should need active conditions after a generation bump", func() {
+		Expect(needsActiveConditions(gkeConfig)).To(BeTrue())
+
+		setActiveConditions(gkeConfig)
+		Expect(gkeConfig.Status.ObservedGeneration).To(Equal(int64(1)))
+		Expect(needsActiveConditions(gkeConfig)).To(BeFalse())
+
+		gkeConfig.Generation = 2
+		Expect(needsActiveConditions(gkeConfig)).To(BeTrue())
+	})
+
+	It("should clear Provisioning and Updating once active", func() {
+		setProvisioningConditions(gkeConfig, reasonCreating)
+		setUpdatingConditions(gkeConfig, stepLabels, "")
+		Expect(needsActiveConditions(gkeConfig)).To(BeTrue())
+
+		setActiveConditions(gkeConfig)
+		Expect(gkev1.ClusterConditionProvisioning.IsFalse(gkeConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionProvisioning.GetReason(gkeConfig)).To(Equal(reasonProvisioned))
+		Expect(gkev1.ClusterConditionUpdating.IsFalse(gkeConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionUpdating.GetReason(gkeConfig)).To(Equal(reasonUpdated))
+		Expect(gkev1.ClusterConditionReady.IsTrue(gkeConfig)).To(BeTrue())
+	})
+
+	It("should raise and then clear the SecurityWarning condition", func() {
+		gkeConfig.Spec.ShieldedNodes = &gkev1.GKEShieldedNodes{Enabled: true}
+		upstreamSpec := &gkev1.GKEClusterConfigSpec{ShieldedNodes: &gkev1.GKEShieldedNodes{Enabled: true}}
+		Expect(setShieldedNodesConditions(gkeConfig, upstreamSpec)).To(BeTrue())
+		Expect(gkeConfig.Status.AppliedShieldedNodes).To(Equal(&gkev1.GKEShieldedNodes{Enabled: true}))
+		Expect(gkev1.ClusterConditionSecurityWarning.IsTrue(gkeConfig)).To(BeFalse())
+		Expect(setShieldedNodesConditions(gkeConfig, upstreamSpec)).To(BeFalse())
+
+		upstreamSpec.ShieldedNodes.Enabled = false
+		Expect(setShieldedNodesConditions(gkeConfig, upstreamSpec)).To(BeTrue())
+		Expect(gkev1.ClusterConditionSecurityWarning.IsTrue(gkeConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionSecurityWarning.GetReason(gkeConfig)).To(Equal(reasonShieldedNodesDisabled))
+		Expect(gkev1.ClusterConditionReady.IsFalse(gkeConfig)).To(BeFalse())
+		Expect(setShieldedNodesConditions(gkeConfig, upstreamSpec)).To(BeFalse())
+
+		upstreamSpec.ShieldedNodes.Enabled = true
+		Expect(setShieldedNodesConditions(gkeConfig, upstreamSpec)).To(BeTrue())
+		Expect(gkev1.ClusterConditionSecurityWarning.IsFalse(gkeConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionSecurityWarning.GetMessage(gkeConfig)).To(BeEmpty())
+	})
+
+	It("should report shielded nodes that were enabled regardless of the spec", func() {
+		gkeConfig.Spec.ShieldedNodes = &gkev1.GKEShieldedNodes{}
+		upstreamSpec := &gkev1.GKEClusterConfigSpec{ShieldedNodes: &gkev1.GKEShieldedNodes{Enabled: true}}
+		Expect(setShieldedNodesConditions(gkeConfig, upstreamSpec)).To(BeTrue())
+		Expect(gkeConfig.Status.AppliedShieldedNodes).To(BeNil())
+		Expect(gkev1.ClusterConditionSecurityWarning.IsTrue(gkeConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionSecurityWarning.GetReason(gkeConfig)).To(Equal(reasonShieldedNodesDrift))
+
+		// disabling shielded nodes after the spec was applied is an update, not drift
+		gkeConfig.Status.AppliedShieldedNodes = &gkev1.GKEShieldedNodes{Enabled: true}
+		Expect(setShieldedNodesConditions(gkeConfig, upstreamSpec)).To(BeTrue())
+		Expect(gkev1.ClusterConditionSecurityWarning.IsFalse(gkeConfig)).To(BeTrue())
+	})
+})
+
+var _ = Describe("condition handling", func() {
+	var (
+		handler   *Handler
+		gkeConfig *gkev1.GKEClusterConfig
+		enqueued  int
+	)
+
+	BeforeEach(func() {
+		enqueued = 0
+		gkeConfig = &gkev1.GKEClusterConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "default",
+			},
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName: "test",
+				ProjectID:   "example-project-name",
+			},
+		}
+		Expect(cl.Create(ctx, gkeConfig)).To(Succeed())
+
+		handler = &Handler{
+			gkeCC:        gkeFactory.Gke().V1().GKEClusterConfig(),
+			secrets:      coreFactory.Core().V1().Secret(),
+			secretsCache: coreFactory.Core().V1().Secret().Cache(),
+			gkeEnqueue: func(_, _ string) {
+				enqueued++
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(test.CleanupAndWait(ctx, cl, gkeConfig)).To(Succeed())
+	})
+
+	It("should only write the status when the update step changes", func() {
+		gotGKEConfig, err := handler.enqueueUpdate(gkeConfig, stepKubernetesVersion, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.Phase).To(Equal(gkeConfigUpdatingPhase))
+		Expect(gkev1.ClusterConditionUpdating.IsTrue(gotGKEConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionUpdating.GetReason(gotGKEConfig)).To(Equal(stepKubernetesVersion))
+		Expect(gotGKEConfig.Status.ObservedGeneration).To(Equal(gotGKEConfig.Generation))
+		Expect(enqueued).To(Equal(0))
+
+		resourceVersion := gotGKEConfig.ResourceVersion
+		gotGKEConfig, err = handler.enqueueUpdate(gotGKEConfig, stepKubernetesVersion, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.ResourceVersion).To(Equal(resourceVersion))
+		Expect(enqueued).To(Equal(1))
+
+		gotGKEConfig, err = handler.enqueueUpdate(gotGKEConfig, stepLabels, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.ResourceVersion).NotTo(Equal(resourceVersion))
+		Expect(gkev1.ClusterConditionUpdating.GetReason(gotGKEConfig)).To(Equal(stepLabels))
+		Expect(enqueued).To(Equal(1))
+	})
+
+	It("should record CredentialsValid false and then true", func() {
+		gotGKEConfig, err := handler.credentialsInvalid(gkeConfig, fmt.Errorf("invalid credential"))
+		Expect(err).To(HaveOccurred())
+		Expect(gkev1.ClusterConditionCredentialsValid.IsFalse(gotGKEConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionCredentialsValid.GetMessage(gotGKEConfig)).To(Equal("invalid credential"))
+
+		// the condition is not re-written when the message has not changed
+		resourceVersion := gotGKEConfig.ResourceVersion
+		gotGKEConfig, err = handler.credentialsInvalid(gotGKEConfig, fmt.Errorf("invalid credential"))
+		Expect(err).To(HaveOccurred())
+		Expect(gotGKEConfig.ResourceVersion).To(Equal(resourceVersion))
+
+		gotGKEConfig = gotGKEConfig.DeepCopy()
+		setCredentialsConditions(gotGKEConfig, nil)
+		gotGKEConfig, err = handler.gkeCC.UpdateStatus(gotGKEConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gkev1.ClusterConditionCredentialsValid.IsTrue(gotGKEConfig)).To(BeTrue())
+	})
+
+	It("should record Degraded on error and clear it on success", func() {
+		failing := true
+		onChange := handler.recordError(func(_ string, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
+			if failing {
+				return config, fmt.Errorf("error updating cluster")
+			}
+			return config, nil
+		})
+
+		gotGKEConfig, err := onChange("", gkeConfig)
+		Expect(err).To(HaveOccurred())
+		Expect(gotGKEConfig.Status.FailureMessage).To(Equal("error updating cluster"))
+		Expect(gkev1.ClusterConditionDegraded.IsTrue(gotGKEConfig)).To(BeTrue())
+		Expect(gotGKEConfig.Status.ObservedGeneration).To(BeZero())
+
+		failing = false
+		gotGKEConfig, err = onChange("", gotGKEConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.FailureMessage).To(BeEmpty())
+		Expect(gkev1.ClusterConditionDegraded.IsFalse(gotGKEConfig)).To(BeTrue())
+	})
+
+	It("should record the Deleting condition once", func() {
+		gotGKEConfig := handler.recordDeleting(gkeConfig)
+		Expect(gkev1.ClusterConditionDeleting.IsTrue(gotGKEConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionReady.IsFalse(gotGKEConfig)).To(BeTrue())
+
+		resourceVersion := gotGKEConfig.ResourceVersion
+		gotGKEConfig = handler.recordDeleting(gotGKEConfig)
+		Expect(gotGKEConfig.ResourceVersion).To(Equal(resourceVersion))
+	})
+})
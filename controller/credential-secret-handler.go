@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+)
+
+// credentialSecretIndex indexes GKEClusterConfigs by the namespace/name of their credential secret.
+const credentialSecretIndex = "gke.cattle.io/credential-secret"
+
+func credentialSecretIndexer(config *gkev1.GKEClusterConfig) ([]string, error) {
+	if config.Spec.GoogleCredentialSecret == "" {
+		return nil, nil
+	}
+	ns, name := parseCredential(config.Spec.GoogleCredentialSecret)
+	return []string{secretKey(ns, name)}, nil
+}
+
+// OnCredentialSecretChanged enqueues every GKEClusterConfig using the secret as its credential, so a
+// rotated or fixed cloud credential is picked up without waiting for an unrelated event.
+func (h *Handler) OnCredentialSecretChanged(key string, secret *corev1.Secret) (*corev1.Secret, error) {
+	if secret == nil {
+		// the secret was deleted, its client can't be used anymore
+		h.gkeClients.invalidate(key)
+	}
+
+	// the key of a secret is its namespace/name, which is also how the configs are indexed
+	configs, err := h.gkeCache.GetByIndex(credentialSecretIndex, key)
+	if err != nil {
+		return secret, err
+	}
+	for _, config := range configs {
+		logrus.Infof("Credential secret [%s] changed, enqueueing cluster [%s (id: %s)]", key, config.Spec.ClusterName, config.Name)
+		h.gkeEnqueue(config.Namespace, config.Name)
+	}
+	return secret, nil
+}
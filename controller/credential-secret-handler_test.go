@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	gkecontrollers "github.com/rancher/gke-operator/pkg/generated/controllers/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/gke/services/mock_services"
+)
+
+// indexedGKEConfigCache looks up configs with the credential secret indexer instead of an informer.
+type indexedGKEConfigCache struct {
+	gkecontrollers.GKEClusterConfigCache
+	configs []*gkev1.GKEClusterConfig
+}
+
+func (c *indexedGKEConfigCache) GetByIndex(indexName, key string) ([]*gkev1.GKEClusterConfig, error) {
+	Expect(indexName).To(Equal(credentialSecretIndex))
+	var configs []*gkev1.GKEClusterConfig
+	for _, config := range c.configs {
+		keys, err := credentialSecretIndexer(config)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range keys {
+			if k == key {
+				configs = append(configs, config)
+			}
+		}
+	}
+	return configs, nil
+}
+
+var _ = Describe("OnCredentialSecretChanged", func() {
+	var (
+		mockController *gomock.Controller
+		handler        *Handler
+		secret         *corev1.Secret
+		enqueued       []string
+	)
+
+	newConfig := func(name, credential string) *gkev1.GKEClusterConfig {
+		return &gkev1.GKEClusterConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName:            name,
+				GoogleCredentialSecret: credential,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		enqueued = nil
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cc-test",
+				Namespace: "cattle-global-data",
+			},
+		}
+
+		handler = &Handler{
+			gkeCache: &indexedGKEConfigCache{
+				configs: []*gkev1.GKEClusterConfig{
+					newConfig("cluster-1", "cattle-global-data:cc-test"),
+					newConfig("cluster-2", "cattle-global-data:cc-other"),
+					newConfig("cluster-3", "cattle-global-data:cc-test"),
+					newConfig("cluster-4", ""),
+				},
+			},
+			gkeClients: newGKEClientCache(),
+			gkeEnqueue: func(namespace, name string) {
+				enqueued = append(enqueued, namespace+"/"+name)
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should index configs by their credential secret", func() {
+		keys, err := credentialSecretIndexer(newConfig("cluster-1", "cattle-global-data:cc-test"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keys).To(Equal([]string{"cattle-global-data/cc-test"}))
+
+		keys, err = credentialSecretIndexer(newConfig("cluster-4", ""))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keys).To(BeEmpty())
+	})
+
+	It("should enqueue the configs using the secret", func() {
+		gotSecret, err := handler.OnCredentialSecretChanged("cattle-global-data/cc-test", secret)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotSecret).To(Equal(secret))
+		Expect(enqueued).To(Equal([]string{"default/cluster-1", "default/cluster-3"}))
+	})
+
+	It("should not enqueue anything for an unused secret", func() {
+		_, err := handler.OnCredentialSecretChanged("default/unrelated", &corev1.Secret{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(enqueued).To(BeEmpty())
+	})
+
+	It("should drop the cached client of a deleted secret", func() {
+		handler.gkeClients.clients["cattle-global-data/cc-test"] = cachedGKEClient{
+			resourceVersion: "1",
+			client:          mock_services.NewMockGKEClusterService(mockController),
+		}
+
+		_, err := handler.OnCredentialSecretChanged("cattle-global-data/cc-test", nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(handler.gkeClients.clients).To(BeEmpty())
+		Expect(enqueued).To(Equal([]string{"default/cluster-1", "default/cluster-3"}))
+	})
+})
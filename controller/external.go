@@ -10,6 +10,7 @@ import (
 	wranglerv1 "github.com/rancher/wrangler/v3/pkg/generated/controllers/core/v1"
 	"golang.org/x/oauth2"
 	gkeapi "google.golang.org/api/container/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -22,14 +23,22 @@ func parseCredential(ref string) (namespace string, name string) {
 }
 
 func GetSecret(_ context.Context, secretsClient wranglerv1.SecretClient, configSpec *gkev1.GKEClusterConfigSpec) (string, error) {
-	ns, id := parseCredential(configSpec.GoogleCredentialSecret)
-	secret, err := secretsClient.Get(ns, id, metav1.GetOptions{})
+	secret, err := getCredentialSecret(secretsClient, configSpec)
 	if err != nil {
 		return "", err
 	}
+	return credentialFromSecret(secret)
+}
+
+func getCredentialSecret(secretsClient wranglerv1.SecretClient, configSpec *gkev1.GKEClusterConfigSpec) (*corev1.Secret, error) {
+	ns, id := parseCredential(configSpec.GoogleCredentialSecret)
+	return secretsClient.Get(ns, id, metav1.GetOptions{})
+}
+
+func credentialFromSecret(secret *corev1.Secret) (string, error) {
 	dataBytes, ok := secret.Data["googlecredentialConfig-authEncodedJson"]
 	if !ok {
-		return "", fmt.Errorf("could not read malformed cloud credential secret %s from namespace %s", id, ns)
+		return "", fmt.Errorf("could not read malformed cloud credential secret %s from namespace %s", secret.Name, secret.Namespace)
 	}
 	return string(dataBytes), nil
 }
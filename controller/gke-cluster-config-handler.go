@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
@@ -19,12 +20,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 )
 
 const (
 	gkeClusterConfigKind     = "GKEClusterConfig"
 	controllerName           = "gke-controller"
 	controllerRemoveName     = "gke-controller-remove"
+	controllerSecretName     = "gke-controller-credential-secret"
 	gkeConfigCreatingPhase   = "creating"
 	gkeConfigNotCreatedPhase = ""
 	gkeConfigActivePhase     = "active"
@@ -61,15 +64,29 @@ const (
 	//  NodePoolStatusStopping The STOPPING state indicates the node pool is being
 	// deleted.
 	NodePoolStatusStopping = "STOPPING"
+
+	// NodePoolStatusRunning The RUNNING state indicates the node pool has been
+	// created and is fully usable.
+	NodePoolStatusRunning = "RUNNING"
+
+	// NodePoolStatusRunningWithError The RUNNING_WITH_ERROR state indicates the
+	// node pool has been created but is partially usable.
+	NodePoolStatusRunningWithError = "RUNNING_WITH_ERROR"
+
+	// NodePoolStatusError The ERROR state indicates the node pool may be unusable.
+	NodePoolStatusError = "ERROR"
 )
 
 type Handler struct {
 	gkeCC           gkecontrollers.GKEClusterConfigClient
+	gkeCache        gkecontrollers.GKEClusterConfigCache
 	gkeEnqueueAfter func(namespace, name string, duration time.Duration)
 	gkeEnqueue      func(namespace, name string)
 	secrets         wranglerv1.SecretClient
 	secretsCache    wranglerv1.SecretCache
-	gkeClient       services.GKEClusterService
+	gkeClients      *gkeClientCache
+	// downstreamClient returns a client for the Kubernetes API of the GKE cluster
+	downstreamClient func(ctx context.Context, config *gkev1.GKEClusterConfig) (kubernetes.Interface, error)
 }
 
 func Register(
@@ -79,14 +96,20 @@ func Register(
 
 	controller := &Handler{
 		gkeCC:           gke,
+		gkeCache:        gke.Cache(),
 		gkeEnqueue:      gke.Enqueue,
 		gkeEnqueueAfter: gke.EnqueueAfter,
 		secretsCache:    secrets.Cache(),
 		secrets:         secrets,
+		gkeClients:      newGKEClientCache(),
 	}
+	controller.downstreamClient = controller.newDownstreamClient
+
+	gke.Cache().AddIndexer(credentialSecretIndex, credentialSecretIndexer)
 
 	gke.OnChange(ctx, controllerName, controller.recordError(controller.OnGkeConfigChanged))
 	gke.OnRemove(ctx, controllerRemoveName, controller.OnGkeConfigRemoved)
+	secrets.OnChange(ctx, controllerSecretName, controller.OnCredentialSecretChanged)
 }
 
 func (h *Handler) OnGkeConfigChanged(_ string, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
@@ -102,34 +125,71 @@ func (h *Handler) OnGkeConfigChanged(_ string, config *gkev1.GKEClusterConfig) (
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	cred, err := GetSecret(ctx, h.secrets, &config.Spec)
+	gkeClient, err := h.gkeClients.get(h.secretsCache, &config.Spec)
 	if err != nil {
-		return config, err
+		return h.credentialsInvalid(config, err)
 	}
 
-	gkeClient, err := gke.GetGKEClusterClient(ctx, cred)
-	if err != nil {
-		return config, err
+	if !gkev1.ClusterConditionCredentialsValid.IsTrue(config) {
+		config = config.DeepCopy()
+		setCredentialsConditions(config, nil)
+		config, err = h.gkeCC.UpdateStatus(config)
+		if err != nil {
+			return config, err
+		}
 	}
 
-	h.gkeClient = gkeClient
-
 	switch config.Status.Phase {
 	case gkeConfigImportingPhase:
-		return h.importCluster(ctx, config)
+		return h.importCluster(ctx, gkeClient, config)
 	case gkeConfigNotCreatedPhase:
-		return h.create(ctx, config)
+		return h.create(ctx, gkeClient, config)
 	case gkeConfigCreatingPhase:
-		return h.waitForCreationComplete(ctx, config)
+		return h.waitForCreationComplete(ctx, gkeClient, config)
 	case gkeConfigActivePhase:
-		return h.checkAndUpdate(ctx, config)
+		return h.checkAndUpdate(ctx, gkeClient, config)
 	case gkeConfigUpdatingPhase:
-		return h.checkAndUpdate(ctx, config)
+		return h.checkAndUpdate(ctx, gkeClient, config)
 	}
 
 	return config, nil
 }
 
+// credentialsInvalid sets the CredentialsValid condition to false and returns the given error,
+// which is recorded as the failure message by recordError.
+func (h *Handler) credentialsInvalid(config *gkev1.GKEClusterConfig, err error) (*gkev1.GKEClusterConfig, error) {
+	if gkev1.ClusterConditionCredentialsValid.IsFalse(config) &&
+		gkev1.ClusterConditionCredentialsValid.GetMessage(config) == err.Error() {
+		return config, err
+	}
+
+	config = config.DeepCopy()
+	setCredentialsConditions(config, err)
+	updated, updateErr := h.gkeCC.UpdateStatus(config)
+	if updateErr != nil {
+		logrus.Errorf("Error recording gkecc [%s (id: %s)] credentials condition: %s, original error: %s", config.Spec.ClusterName, config.Name, updateErr, err)
+		return config, err
+	}
+	return updated, err
+}
+
+// recordDeleting sets the Deleting condition before the upstream cluster is removed. Failing to
+// record it does not block the removal.
+func (h *Handler) recordDeleting(config *gkev1.GKEClusterConfig) *gkev1.GKEClusterConfig {
+	if gkev1.ClusterConditionDeleting.IsTrue(config) {
+		return config
+	}
+
+	config = config.DeepCopy()
+	setDeletingConditions(config)
+	updated, err := h.gkeCC.UpdateStatus(config)
+	if err != nil {
+		logrus.Warnf("Error recording deleting condition for cluster [%s (id: %s)]: %v", config.Spec.ClusterName, config.Name, err)
+		return config
+	}
+	return updated
+}
+
 // recordError writes the error return by onChange to the failureMessage field on status. If there is no error, then
 // empty string will be written to status
 func (h *Handler) recordError(onChange func(key string, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error)) func(key string, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
@@ -164,6 +224,7 @@ func (h *Handler) recordError(onChange func(key string, config *gkev1.GKECluster
 			}
 		}
 		config.Status.FailureMessage = message
+		setErrorConditions(config, message)
 
 		var recordErr error
 		config, recordErr = h.gkeCC.UpdateStatus(config)
@@ -176,8 +237,8 @@ func (h *Handler) recordError(onChange func(key string, config *gkev1.GKECluster
 
 // importCluster returns an active cluster spec containing the given config's clusterName and region/zone
 // and creates a Secret containing the cluster's CA and endpoint retrieved from the cluster object.
-func (h *Handler) importCluster(ctx context.Context, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
-	cluster, err := gke.GetCluster(ctx, h.gkeClient, &config.Spec)
+func (h *Handler) importCluster(ctx context.Context, gkeClient services.GKEClusterService, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
+	cluster, err := gke.GetCluster(ctx, gkeClient, &config.Spec)
 	if err != nil {
 		return config, err
 	}
@@ -185,7 +246,9 @@ func (h *Handler) importCluster(ctx context.Context, config *gkev1.GKEClusterCon
 		return config, err
 	}
 
+	config = config.DeepCopy()
 	config.Status.Phase = gkeConfigActivePhase
+	setActiveConditions(config)
 	return h.gkeCC.UpdateStatus(config)
 }
 
@@ -193,18 +256,11 @@ func (h *Handler) OnGkeConfigRemoved(_ string, config *gkev1.GKEClusterConfig) (
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	cred, err := GetSecret(ctx, h.secrets, &config.Spec)
+	gkeClient, err := h.gkeClients.get(h.secretsCache, &config.Spec)
 	if err != nil {
 		return config, err
 	}
 
-	gkeClient, err := gke.GetGKEClusterClient(ctx, cred)
-	if err != nil {
-		return config, err
-	}
-
-	h.gkeClient = gkeClient
-
 	if config.Spec.Imported {
 		logrus.Infof("Cluster [%s (id: %s)] is imported, will not delete GKE cluster", config.Spec.ClusterName, config.Name)
 		return config, nil
@@ -215,8 +271,28 @@ func (h *Handler) OnGkeConfigRemoved(_ string, config *gkev1.GKEClusterConfig) (
 		return config, nil
 	}
 
+	config = h.recordDeleting(config)
+
+	if config.Status.PendingOperation != nil {
+		// GKE rejects deleting a cluster while an operation is running on it, so the pending
+		// operation is cancelled and the removal is retried until it is done
+		op, err := gke.GetOperation(ctx, gkeClient, &config.Spec, config.Status.PendingOperation.Name)
+		if err != nil {
+			return config, err
+		}
+		if op != nil && op.Status != gke.OperationStatusDone {
+			if op.Status != gke.OperationStatusAborting {
+				logrus.Infof("Cancelling operation %s [%s] on cluster [%s (id: %s)]", op.Name, op.OperationType, config.Spec.ClusterName, config.Name)
+				if err := gke.CancelOperation(ctx, gkeClient, &config.Spec, op.Name); err != nil {
+					logrus.Warnf("Error cancelling operation %s on cluster [%s (id: %s)]: %v", op.Name, config.Spec.ClusterName, config.Name, err)
+				}
+			}
+			return config, fmt.Errorf("waiting for operation %s [%s] on cluster [%s (id: %s)] to finish before deleting", op.Name, op.OperationType, config.Spec.ClusterName, config.Name)
+		}
+	}
+
 	logrus.Infof("Removing cluster [%s (id: %s)] from project %s, region/zone %s", config.Spec.ClusterName, config.Name, config.Spec.ProjectID, gke.Location(config.Spec.Region, config.Spec.Zone))
-	if err := gke.RemoveCluster(ctx, h.gkeClient, config); err != nil {
+	if err := gke.RemoveCluster(ctx, gkeClient, config); err != nil {
 		logrus.Debugf("Error deleting cluster %s: %v", config.Spec.ClusterName, err)
 		return config, err
 	}
@@ -224,25 +300,38 @@ func (h *Handler) OnGkeConfigRemoved(_ string, config *gkev1.GKEClusterConfig) (
 	return config, nil
 }
 
-func (h *Handler) create(ctx context.Context, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
+func (h *Handler) create(ctx context.Context, gkeClient services.GKEClusterService, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
 	if config.Spec.Imported {
 		logrus.Infof("Importing cluster [%s (id: %s)]", config.Spec.ClusterName, config.Name)
 		config = config.DeepCopy()
 		config.Status.Phase = gkeConfigImportingPhase
+		setProvisioningConditions(config, reasonImporting)
 		return h.gkeCC.UpdateStatus(config)
 	}
 
-	if err := gke.Create(ctx, h.gkeClient, config); err != nil {
+	recorder := gke.NewOperationRecorder(gkeClient)
+	if err := gke.Create(ctx, recorder, config); err != nil {
 		return config, err
 	}
 
 	config = config.DeepCopy()
 	config.Status.Phase = gkeConfigCreatingPhase
+	config.Status.PendingOperation = gke.BuildOperationStatus(recorder.Operation())
+	setProvisioningConditions(config, reasonCreating)
 	return h.gkeCC.UpdateStatus(config)
 }
 
-func (h *Handler) checkAndUpdate(ctx context.Context, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
-	cluster, err := gke.GetCluster(ctx, h.gkeClient, &config.Spec)
+func (h *Handler) checkAndUpdate(ctx context.Context, gkeClient services.GKEClusterService, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
+	if config.Status.PendingOperation != nil {
+		var done bool
+		var err error
+		config, done, err = h.waitForPendingOperation(ctx, gkeClient, config)
+		if err != nil || !done {
+			return config, err
+		}
+	}
+
+	cluster, err := gke.GetCluster(ctx, gkeClient, &config.Spec)
 	if err != nil {
 		return config, err
 	}
@@ -250,9 +339,22 @@ func (h *Handler) checkAndUpdate(ctx context.Context, config *gkev1.GKEClusterCo
 	if cluster.Status == ClusterStatusReconciling {
 		// upstream cluster is already updating, must wait until sending next update
 		logrus.Infof("Waiting for cluster [%s (id: %s)] to finish updating", config.Spec.ClusterName, config.Name)
+		op, err := gke.FindPendingOperation(ctx, gkeClient, &config.Spec)
+		if err != nil {
+			return config, err
+		}
+		if op != nil {
+			// track the operation so the next reconcile waits on it
+			config = config.DeepCopy()
+			config.Status.Phase = gkeConfigUpdatingPhase
+			config.Status.PendingOperation = gke.BuildOperationStatus(op)
+			setUpdatingConditions(config, reasonClusterReconciling, operationMessage(config.Status.PendingOperation))
+			return h.gkeCC.UpdateStatus(config)
+		}
 		if config.Status.Phase != gkeConfigUpdatingPhase {
 			config = config.DeepCopy()
 			config.Status.Phase = gkeConfigUpdatingPhase
+			setUpdatingConditions(config, reasonClusterReconciling, "")
 			return h.gkeCC.UpdateStatus(config)
 		}
 		h.gkeEnqueueAfter(config.Namespace, config.Name, 30*time.Second)
@@ -265,6 +367,7 @@ func (h *Handler) checkAndUpdate(ctx context.Context, config *gkev1.GKEClusterCo
 			if config.Status.Phase != gkeConfigUpdatingPhase {
 				config = config.DeepCopy()
 				config.Status.Phase = gkeConfigUpdatingPhase
+				setUpdatingConditions(config, reasonNodePoolBusy, fmt.Sprintf("node pool [%s] is %s", np.Name, np.Status))
 				config, err = h.gkeCC.UpdateStatus(config)
 				if err != nil {
 					return config, err
@@ -276,37 +379,141 @@ func (h *Handler) checkAndUpdate(ctx context.Context, config *gkev1.GKEClusterCo
 		}
 	}
 
+	// the endpoints can change after the cluster was created, e.g. when the DNS endpoint is enabled
+	if err := h.createCASecret(config, cluster); err != nil {
+		return config, err
+	}
+
 	upstreamSpec, err := h.buildUpstreamClusterState(cluster)
 	if err != nil {
 		return config, err
 	}
 
-	return h.updateUpstreamClusterState(ctx, config, upstreamSpec)
+	return h.updateUpstreamClusterState(ctx, gkeClient, config, upstreamSpec)
 }
 
-// enqueueUpdate enqueues the config if it is already in the updating phase. Otherwise, the
-// phase is updated to "updating". This is important because the object needs to reenter the
-// onChange handler to start waiting on the update.
-func (h *Handler) enqueueUpdate(config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
-	if config.Status.Phase == gkeConfigUpdatingPhase {
+// enqueueUpdate enqueues the config if it is already in the updating phase for the given step.
+// Otherwise, the phase is updated to "updating" and the Updating condition records the step. This
+// is important because the object needs to reenter the onChange handler to start waiting on the update.
+// If the update started an operation, it is recorded in the status so the next reconcile waits on it.
+func (h *Handler) enqueueUpdate(config *gkev1.GKEClusterConfig, step string, operation *gkeapi.Operation) (*gkev1.GKEClusterConfig, error) {
+	if operation == nil &&
+		config.Status.Phase == gkeConfigUpdatingPhase &&
+		gkev1.ClusterConditionUpdating.IsTrue(config) &&
+		gkev1.ClusterConditionUpdating.GetReason(config) == step &&
+		config.Status.ObservedGeneration == config.Generation {
 		h.gkeEnqueue(config.Namespace, config.Name)
 		return config, nil
 	}
 	config = config.DeepCopy()
+	setUpdatingStatus(config, step, operation)
+	return h.gkeCC.UpdateStatus(config)
+}
+
+// setUpdatingStatus sets the phase to "updating" and the Updating condition to the given step,
+// recording the operation started by the step, if any.
+func setUpdatingStatus(config *gkev1.GKEClusterConfig, step string, operation *gkeapi.Operation) {
 	config.Status.Phase = gkeConfigUpdatingPhase
+	// an update step was issued for this generation of the spec
+	config.Status.ObservedGeneration = config.Generation
+	config.Status.PendingOperation = gke.BuildOperationStatus(operation)
+	setUpdatingConditions(config, step, operationMessage(config.Status.PendingOperation))
+}
+
+// waitForUpdate records in the Updating condition that the given update step is still being applied
+// upstream without an operation to wait on, and enqueues the config to check it again later. The status
+// is only written when the step or its message changes.
+func (h *Handler) waitForUpdate(config *gkev1.GKEClusterConfig, step, message string) (*gkev1.GKEClusterConfig, error) {
+	h.gkeEnqueueAfter(config.Namespace, config.Name, wait*time.Second)
+	if config.Status.Phase == gkeConfigUpdatingPhase &&
+		gkev1.ClusterConditionUpdating.IsTrue(config) &&
+		gkev1.ClusterConditionUpdating.GetReason(config) == step &&
+		gkev1.ClusterConditionUpdating.GetMessage(config) == message &&
+		config.Status.ObservedGeneration == config.Generation {
+		return config, nil
+	}
+	config = config.DeepCopy()
+	setUpdatingStatus(config, step, nil)
+	setUpdatingConditions(config, step, message)
 	return h.gkeCC.UpdateStatus(config)
 }
 
-func (h *Handler) updateUpstreamClusterState(ctx context.Context, config *gkev1.GKEClusterConfig, upstreamSpec *gkev1.GKEClusterConfigSpec) (*gkev1.GKEClusterConfig, error) {
-	changed, err := gke.UpdateMasterKubernetesVersion(ctx, h.gkeClient, config, upstreamSpec)
+// waitForPendingOperation checks the operation recorded in the status. While it is running, its
+// progress is recorded and the config is enqueued to be checked again later. Once it is done, it is
+// cleared from the status and the error it finished with, if any, is returned so that it is
+// recorded as the failure message.
+func (h *Handler) waitForPendingOperation(ctx context.Context, gkeClient services.GKEClusterService, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, bool, error) {
+	op, err := gke.GetOperation(ctx, gkeClient, &config.Spec, config.Status.PendingOperation.Name)
+	if err != nil {
+		return config, false, err
+	}
+
+	if op != nil && op.Status != gke.OperationStatusDone {
+		logrus.Infof("Waiting for operation %s [%s] on cluster [%s (id: %s)]", op.Name, op.OperationType, config.Spec.ClusterName, config.Name)
+		if operation := gke.BuildOperationStatus(op); *operation != *config.Status.PendingOperation {
+			config = config.DeepCopy()
+			config.Status.PendingOperation = operation
+			config, err = h.gkeCC.UpdateStatus(config)
+			return config, false, err
+		}
+		h.gkeEnqueueAfter(config.Namespace, config.Name, wait*time.Second)
+		return config, false, nil
+	}
+
+	config = config.DeepCopy()
+	config.Status.PendingOperation = nil
+	config, err = h.gkeCC.UpdateStatus(config)
+	if err != nil {
+		return config, false, err
+	}
+	if op == nil {
+		return config, true, nil
+	}
+	return config, true, gke.OperationError(op)
+}
+
+func (h *Handler) updateUpstreamClusterState(ctx context.Context, gkeClient services.GKEClusterService, config *gkev1.GKEClusterConfig, upstreamSpec *gkev1.GKEClusterConfigSpec) (*gkev1.GKEClusterConfig, error) {
+	recorder := gke.NewOperationRecorder(gkeClient)
+
+	// the SecurityWarning condition follows the upstream cluster, whether or not shielded nodes
+	// were changed by the operator
+	if updated := config.DeepCopy(); setShieldedNodesConditions(updated, upstreamSpec) {
+		return h.gkeCC.UpdateStatus(updated)
+	}
+
+	// GKE re-encrypts all the secrets of the cluster long after the database encryption update
+	// is done, no other update is sent until it finishes
+	changed, err := gke.UpdateDatabaseEncryption(ctx, recorder, config, upstreamSpec)
+	if err != nil {
+		return config, err
+	}
+	if changed == gke.Retry {
+		return h.waitForUpdate(config, stepDatabaseEncryption,
+			fmt.Sprintf("waiting for the secrets to be re-encrypted, current state %s", upstreamSpec.DatabaseEncryption.CurrentState))
+	}
+	if changed == gke.Changed {
+		return h.enqueueUpdate(config, stepDatabaseEncryption, recorder.Operation())
+	}
+
+	// the release channel determines which versions are available and whether GKE upgrades the
+	// cluster on its own, so it is updated before the version
+	changed, err = gke.UpdateReleaseChannel(ctx, recorder, config, upstreamSpec)
+	if err != nil {
+		return config, err
+	}
+	if changed == gke.Changed {
+		return h.enqueueUpdate(config, stepReleaseChannel, recorder.Operation())
+	}
+
+	changed, err = gke.UpdateMasterKubernetesVersion(ctx, recorder, config, upstreamSpec)
 	if err != nil {
 		return config, err
 	}
 	if changed == gke.Changed {
-		return h.enqueueUpdate(config)
+		return h.enqueueUpdate(config, stepKubernetesVersion, recorder.Operation())
 	}
 
-	changed, err = gke.UpdateClusterAddons(ctx, h.gkeClient, config, upstreamSpec)
+	changed, err = gke.UpdateClusterAddons(ctx, recorder, config, upstreamSpec)
 	if err != nil {
 		return config, err
 	}
@@ -315,118 +522,277 @@ func (h *Handler) updateUpstreamClusterState(ctx context.Context, config *gkev1.
 		return config, nil
 	}
 	if changed == gke.Changed {
-		return h.enqueueUpdate(config)
+		return h.enqueueUpdate(config, stepClusterAddons, recorder.Operation())
+	}
+
+	changed, err = gke.UpdateMasterAuthorizedNetworks(ctx, recorder, config, upstreamSpec)
+	if err != nil {
+		return config, err
+	}
+	if changed == gke.Changed {
+		return h.enqueueUpdate(config, stepMasterAuthorizedNetworks, recorder.Operation())
+	}
+
+	changed, err = gke.UpdatePrivateClusterConfig(ctx, recorder, config, upstreamSpec)
+	if err != nil {
+		return config, err
+	}
+	if changed == gke.Changed {
+		return h.enqueueUpdate(config, stepPrivateClusterConfig, recorder.Operation())
+	}
+
+	changed, err = gke.UpdateControlPlaneEndpoints(ctx, recorder, config, upstreamSpec)
+	if err != nil {
+		return config, err
+	}
+	if changed == gke.Changed {
+		return h.enqueueUpdate(config, stepControlPlaneEndpoints, recorder.Operation())
+	}
+
+	changed, err = gke.UpdateLoggingMonitoringService(ctx, recorder, config, upstreamSpec)
+	if err != nil {
+		return config, err
+	}
+	if changed == gke.Changed {
+		return h.enqueueUpdate(config, stepLoggingMonitoringService, recorder.Operation())
+	}
+
+	changed, err = gke.UpdateNetworkPolicyEnabled(ctx, recorder, config, upstreamSpec)
+	if err != nil {
+		return config, err
+	}
+	if changed == gke.Changed {
+		return h.enqueueUpdate(config, stepNetworkPolicy, recorder.Operation())
+	}
+
+	changed, err = gke.UpdateDNSConfig(ctx, recorder, config, upstreamSpec)
+	if err != nil {
+		return config, err
+	}
+	if changed == gke.Changed {
+		return h.enqueueUpdate(config, stepDNSConfig, recorder.Operation())
+	}
+
+	changed, err = gke.UpdateStackType(ctx, recorder, config, upstreamSpec)
+	if err != nil {
+		return config, err
+	}
+	if changed == gke.Changed {
+		return h.enqueueUpdate(config, stepStackType, recorder.Operation())
 	}
 
-	changed, err = gke.UpdateMasterAuthorizedNetworks(ctx, h.gkeClient, config, upstreamSpec)
+	changed, err = gke.UpdateAdditionalPodRanges(ctx, recorder, config, upstreamSpec)
 	if err != nil {
 		return config, err
 	}
 	if changed == gke.Changed {
-		return h.enqueueUpdate(config)
+		return h.enqueueUpdate(config, stepAdditionalPodRanges, recorder.Operation())
 	}
 
-	changed, err = gke.UpdateLoggingMonitoringService(ctx, h.gkeClient, config, upstreamSpec)
+	changed, err = gke.UpdateLocations(ctx, recorder, config, upstreamSpec)
 	if err != nil {
 		return config, err
 	}
 	if changed == gke.Changed {
-		return h.enqueueUpdate(config)
+		return h.enqueueUpdate(config, stepLocations, recorder.Operation())
 	}
 
-	changed, err = gke.UpdateNetworkPolicyEnabled(ctx, h.gkeClient, config, upstreamSpec)
+	changed, err = gke.UpdateClusterAutoscaling(ctx, recorder, config, upstreamSpec)
 	if err != nil {
 		return config, err
 	}
 	if changed == gke.Changed {
-		return h.enqueueUpdate(config)
+		return h.enqueueUpdate(config, stepClusterAutoscaling, recorder.Operation())
 	}
 
-	changed, err = gke.UpdateLocations(ctx, h.gkeClient, config, upstreamSpec)
+	changed, err = gke.UpdateMaintenanceWindow(ctx, recorder, config, upstreamSpec)
 	if err != nil {
 		return config, err
 	}
 	if changed == gke.Changed {
-		return h.enqueueUpdate(config)
+		return h.enqueueUpdate(config, stepMaintenanceWindow, recorder.Operation())
 	}
 
-	changed, err = gke.UpdateMaintenanceWindow(ctx, h.gkeClient, config, upstreamSpec)
+	changed, err = gke.UpdateMaintenancePolicy(ctx, recorder, config, upstreamSpec)
 	if err != nil {
 		return config, err
 	}
 	if changed == gke.Changed {
-		return h.enqueueUpdate(config)
+		return h.enqueueUpdate(config, stepMaintenancePolicy, recorder.Operation())
 	}
 
-	changed, err = gke.UpdateLabels(ctx, h.gkeClient, config, upstreamSpec)
+	changed, err = gke.UpdateShieldedNodes(ctx, recorder, config, upstreamSpec)
+	if err != nil {
+		return config, err
+	}
+	if changed == gke.Changed {
+		config = config.DeepCopy()
+		config.Status.AppliedShieldedNodes = config.Spec.ShieldedNodes.DeepCopy()
+		return h.enqueueUpdate(config, stepShieldedNodes, recorder.Operation())
+	}
+
+	changed, err = gke.UpdateWorkloadIdentity(ctx, recorder, config, upstreamSpec)
+	if err != nil {
+		return config, err
+	}
+	if changed == gke.Changed {
+		return h.enqueueUpdate(config, stepWorkloadIdentity, recorder.Operation())
+	}
+
+	changed, err = gke.UpdateLabels(ctx, recorder, config, upstreamSpec)
 	if err != nil {
 		return config, err
 	}
 	if changed == gke.Changed || changed == gke.Retry {
-		return h.enqueueUpdate(config)
+		return h.enqueueUpdate(config, stepLabels, recorder.Operation())
 	}
 
-	if config.Spec.NodePools != nil && (config.Spec.AutopilotConfig == nil || !config.Spec.AutopilotConfig.Enabled) {
+	if config.Spec.NodePools != nil && !gke.IsAutopilot(config) {
 		downstreamNodePools, err := buildNodePoolMap(config.Spec.NodePools, config.Name)
 		if err != nil {
 			return config, err
 		}
 
+		if pruned := pruneNodePoolReplacements(config, downstreamNodePools); pruned != nil {
+			logrus.Infof("Removing replacements of node pools no longer in the spec of cluster [%s (id: %s)]", config.Spec.ClusterName, config.Name)
+			return h.gkeCC.UpdateStatus(pruned)
+		}
+
+		// a node pool replacement in progress is carried out before any other node pool update
+		for npName, replacement := range config.Status.NodePoolReplacements {
+			if np, ok := downstreamNodePools[npName]; ok && replacement.Phase != "" {
+				return h.replaceNodePool(ctx, recorder, config, np)
+			}
+		}
+
 		upstreamNodePools, _ := buildNodePoolMap(upstreamSpec.NodePools, config.Name)
 		nodePoolsNeedUpdate := false
 		for npName, np := range downstreamNodePools {
-			upstreamNodePool, ok := upstreamNodePools[npName]
+			// updates are sent to the upstream node pool backing the node pool, which
+			// has a different name once the node pool was replaced
+			np = withUpstreamName(np, upstreamNodePoolName(config, npName))
+			upstreamNodePool, ok := upstreamNodePools[*np.Name]
 			if ok {
-				// There is a matching nodepool in the cluster already, so update it if needed
-				changed, err = gke.UpdateNodePoolKubernetesVersionOrImageType(ctx, h.gkeClient, np, config, upstreamNodePool)
+				// There is a matching nodepool in the cluster already, so update it if needed.
+				if err := gke.ValidateNodePoolConfig(np, config); err != nil {
+					return config, err
+				}
+				if err := gke.ValidateNodePoolUpdate(np, config, upstreamNodePool); err != nil {
+					if np.ReplacementPolicy != gke.NodePoolReplacementPolicyReplace {
+						return config, err
+					}
+					return h.startNodePoolReplacement(config, npName, err)
+				}
+
+				// The upgrade settings are updated first so that a version update uses them.
+				changed, err = gke.UpdateNodePoolUpgradeSettings(ctx, recorder, np, config, upstreamNodePool)
+				if err != nil {
+					return config, err
+				}
+				if changed == gke.Changed {
+					// only one operation is tracked at a time, so wait on it before
+					// sending updates for the remaining node pools
+					return h.enqueueUpdate(config, stepNodePools, recorder.Operation())
+				}
+				if changed == gke.Retry {
+					nodePoolsNeedUpdate = true
+					// cannot make further updates while an operation is pending,
+					// further updates will be retried if needed on the next reconcile loop
+					continue
+				}
+
+				changed, err = gke.UpdateNodePoolKubernetesVersionOrImageType(ctx, recorder, np, config, upstreamNodePool)
+				if err != nil {
+					return config, err
+				}
+				if changed == gke.Changed {
+					return h.enqueueUpdate(config, stepNodePools, recorder.Operation())
+				}
+				if changed == gke.Retry {
+					nodePoolsNeedUpdate = true
+					// cannot make further updates while an operation is pending,
+					// further updates will be retried if needed on the next reconcile loop
+					continue
+				}
+
+				changed, err = gke.UpdateNodePoolMachineConfig(ctx, recorder, np, config, upstreamNodePool)
 				if err != nil {
 					return config, err
 				}
-				if changed == gke.Changed || changed == gke.Retry {
+				if changed == gke.Changed {
+					return h.enqueueUpdate(config, stepNodePools, recorder.Operation())
+				}
+				if changed == gke.Retry {
+					nodePoolsNeedUpdate = true
+					// cannot make further updates while an operation is pending,
+					// further updates will be retried if needed on the next reconcile loop
+					continue
+				}
+
+				changed, err = gke.UpdateNodePoolLocations(ctx, recorder, np, config, upstreamNodePool)
+				if err != nil {
+					return config, err
+				}
+				if changed == gke.Changed {
+					return h.enqueueUpdate(config, stepNodePools, recorder.Operation())
+				}
+				if changed == gke.Retry {
 					nodePoolsNeedUpdate = true
 					// cannot make further updates while an operation is pending,
 					// further updates will be retried if needed on the next reconcile loop
 					continue
 				}
 
-				changed, err = gke.UpdateNodePoolSize(ctx, h.gkeClient, np, config, upstreamNodePool)
+				changed, err = gke.UpdateNodePoolSize(ctx, recorder, np, config, upstreamNodePool)
 				if err != nil {
 					return config, err
 				}
-				if changed == gke.Changed || changed == gke.Retry {
+				if changed == gke.Changed {
+					return h.enqueueUpdate(config, stepNodePools, recorder.Operation())
+				}
+				if changed == gke.Retry {
 					nodePoolsNeedUpdate = true
 					// cannot make further updates while an operation is pending,
 					// further updates will be retried if needed on the next reconcile loop
 					continue
 				}
 
-				changed, err = gke.UpdateNodePoolAutoscaling(ctx, h.gkeClient, np, config, upstreamNodePool)
+				changed, err = gke.UpdateNodePoolAutoscaling(ctx, recorder, np, config, upstreamNodePool)
 				if err != nil {
 					return config, err
 				}
-				if changed == gke.Changed || changed == gke.Retry {
+				if changed == gke.Changed {
+					return h.enqueueUpdate(config, stepNodePools, recorder.Operation())
+				}
+				if changed == gke.Retry {
 					nodePoolsNeedUpdate = true
 					// cannot make further updates while an operation is pending,
 					// further updates will be retried if needed on the next reconcile loop
 					continue
 				}
 
-				changed, err = gke.UpdateNodePoolManagement(ctx, h.gkeClient, np, config, upstreamNodePool)
+				changed, err = gke.UpdateNodePoolManagement(ctx, recorder, np, config, upstreamNodePool)
 				if err != nil {
 					return config, err
 				}
-				if changed == gke.Changed || changed == gke.Retry {
+				if changed == gke.Changed {
+					return h.enqueueUpdate(config, stepNodePools, recorder.Operation())
+				}
+				if changed == gke.Retry {
 					nodePoolsNeedUpdate = true
 					// cannot make further updates while an operation is pending,
 					// further updates will be retried if needed on the next reconcile loop
 					continue
 				}
 
-				changed, err = gke.UpdateNodePoolConfig(ctx, h.gkeClient, np, config, upstreamNodePool)
+				changed, err = gke.UpdateNodePoolConfig(ctx, recorder, np, config, upstreamNodePool)
 				if err != nil {
 					return config, err
 				}
-				if changed == gke.Changed || changed == gke.Retry {
+				if changed == gke.Changed {
+					return h.enqueueUpdate(config, stepNodePools, recorder.Operation())
+				}
+				if changed == gke.Retry {
 					nodePoolsNeedUpdate = true
 					// cannot make further updates while an operation is pending,
 					// further updates will be retried if needed on the next reconcile loop
@@ -435,44 +801,65 @@ func (h *Handler) updateUpstreamClusterState(ctx context.Context, config *gkev1.
 			} else {
 				// There is no nodepool with this name yet, create it
 				logrus.Infof("Adding node pool [%s] to cluster [%s (id: %s)]", *np.Name, config.Spec.ClusterName, config.Name)
-				if changed, err = gke.CreateNodePool(ctx, h.gkeClient, config, np); err != nil {
+				if changed, err = gke.CreateNodePool(ctx, recorder, config, np); err != nil {
 					return config, err
 				}
-				if changed == gke.Changed || changed == gke.Retry {
+				if changed == gke.Changed {
+					return h.enqueueUpdate(config, stepNodePools, recorder.Operation())
+				}
+				if changed == gke.Retry {
 					nodePoolsNeedUpdate = true
 				}
 			}
 		}
 
-		for npName := range upstreamNodePools {
-			if _, ok := downstreamNodePools[npName]; !ok {
+		ownedNodePools := ownedNodePoolNames(config, downstreamNodePools)
+		for npName, upstreamNodePool := range upstreamNodePools {
+			// node pools created by node auto-provisioning are managed by GKE
+			if upstreamNodePool.Autoscaling != nil && upstreamNodePool.Autoscaling.Autoprovisioned {
+				continue
+			}
+			if !ownedNodePools[npName] {
 				logrus.Infof("Removing node pool [%s] from cluster [%s (id: %s)]", npName, config.Spec.ClusterName, config.Name)
-				if changed, err = gke.RemoveNodePool(ctx, h.gkeClient, config, npName); err != nil {
+				if changed, err = gke.RemoveNodePool(ctx, recorder, config, npName); err != nil {
 					return config, err
 				}
-				if changed == gke.Changed || changed == gke.Retry {
+				if changed == gke.Changed {
+					return h.enqueueUpdate(config, stepNodePools, recorder.Operation())
+				}
+				if changed == gke.Retry {
 					nodePoolsNeedUpdate = true
 				}
 			}
 		}
 		if nodePoolsNeedUpdate {
-			return h.enqueueUpdate(config)
+			return h.enqueueUpdate(config, stepNodePools, nil)
 		}
 	}
 
 	// no new updates, set to active
-	if config.Status.Phase != gkeConfigActivePhase {
+	if config.Status.Phase != gkeConfigActivePhase || needsActiveConditions(config) {
 		logrus.Infof("Cluster [%s (id: %s)] finished updating", config.Spec.ClusterName, config.Name)
 		config = config.DeepCopy()
 		config.Status.Phase = gkeConfigActivePhase
+		setActiveConditions(config)
 		return h.gkeCC.UpdateStatus(config)
 	}
 
 	return config, nil
 }
 
-func (h *Handler) waitForCreationComplete(ctx context.Context, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
-	cluster, err := gke.GetCluster(ctx, h.gkeClient, &config.Spec)
+func (h *Handler) waitForCreationComplete(ctx context.Context, gkeClient services.GKEClusterService, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
+	if config.Status.PendingOperation != nil {
+		var done bool
+		var err error
+		config, done, err = h.waitForPendingOperation(ctx, gkeClient, config)
+		if err != nil || !done {
+			return config, err
+		}
+	}
+
+	cluster, err := gke.GetCluster(ctx, gkeClient, &config.Spec)
 	if err != nil {
 		return config, err
 	}
@@ -487,6 +874,7 @@ func (h *Handler) waitForCreationComplete(ctx context.Context, config *gkev1.GKE
 		logrus.Infof("Cluster [%s (id: %s)] is running", config.Spec.ClusterName, config.Name)
 		config = config.DeepCopy()
 		config.Status.Phase = gkeConfigActivePhase
+		setActiveConditions(config)
 		return h.gkeCC.UpdateStatus(config)
 	}
 	logrus.Infof("Waiting for cluster [%s (id: %s)] to finish creating", config.Spec.ClusterName, config.Name)
@@ -499,13 +887,11 @@ func (h *Handler) buildUpstreamClusterState(cluster *gkeapi.Cluster) (*gkev1.GKE
 	newSpec := &gkev1.GKEClusterConfigSpec{
 		KubernetesVersion:     &cluster.CurrentMasterVersion,
 		EnableKubernetesAlpha: &cluster.EnableKubernetesAlpha,
-		ClusterAddons:         &gkev1.GKEClusterAddons{},
 		ClusterIpv4CidrBlock:  &cluster.ClusterIpv4Cidr,
 		LoggingService:        &cluster.LoggingService,
 		MonitoringService:     &cluster.MonitoringService,
 		Network:               &cluster.Network,
 		Subnetwork:            &cluster.Subnetwork,
-		PrivateClusterConfig:  &gkev1.GKEPrivateClusterConfig{},
 		IPAllocationPolicy:    &gkev1.GKEIPAllocationPolicy{},
 		MasterAuthorizedNetworksConfig: &gkev1.GKEMasterAuthorizedNetworksConfig{
 			Enabled: false,
@@ -524,33 +910,23 @@ func (h *Handler) buildUpstreamClusterState(cluster *gkeapi.Cluster) (*gkev1.GKE
 	}
 	newSpec.NetworkPolicyEnabled = &networkPolicyEnabled
 
-	if cluster.PrivateClusterConfig != nil {
-		newSpec.PrivateClusterConfig.EnablePrivateEndpoint = cluster.PrivateClusterConfig.EnablePrivateEndpoint
-		newSpec.PrivateClusterConfig.EnablePrivateNodes = cluster.PrivateClusterConfig.EnablePrivateNodes
-		newSpec.PrivateClusterConfig.MasterIpv4CidrBlock = cluster.PrivateClusterConfig.MasterIpv4CidrBlock
-	} else {
-		newSpec.PrivateClusterConfig.EnablePrivateEndpoint = false
-		newSpec.PrivateClusterConfig.EnablePrivateNodes = false
+	datapathProvider := ""
+	var dnsConfig *gkeapi.DNSConfig
+	if cluster.NetworkConfig != nil {
+		datapathProvider = cluster.NetworkConfig.DatapathProvider
+		dnsConfig = cluster.NetworkConfig.DnsConfig
 	}
+	newSpec.DatapathProvider = &datapathProvider
+	newSpec.DNSConfig = gke.BuildDNSConfig(dnsConfig)
+
+	newSpec.PrivateClusterConfig = gke.BuildPrivateClusterConfig(cluster.PrivateClusterConfig)
+	newSpec.ControlPlaneEndpointsConfig = gke.BuildControlPlaneEndpointsConfig(cluster.ControlPlaneEndpointsConfig)
+	newSpec.DatabaseEncryption = gke.BuildDatabaseEncryption(cluster.DatabaseEncryption)
+	newSpec.WorkloadIdentityConfig = gke.BuildWorkloadIdentityConfig(cluster.WorkloadIdentityConfig)
+	newSpec.ShieldedNodes = gke.BuildShieldedNodes(cluster.ShieldedNodes)
 
 	// build cluster addons
-	if cluster.AddonsConfig != nil {
-		lb := true
-		if cluster.AddonsConfig.HttpLoadBalancing != nil {
-			lb = !cluster.AddonsConfig.HttpLoadBalancing.Disabled
-		}
-		newSpec.ClusterAddons.HTTPLoadBalancing = lb
-		hpa := true
-		if cluster.AddonsConfig.HorizontalPodAutoscaling != nil {
-			hpa = !cluster.AddonsConfig.HorizontalPodAutoscaling.Disabled
-		}
-		newSpec.ClusterAddons.HorizontalPodAutoscaling = hpa
-		npc := true
-		if cluster.AddonsConfig.NetworkPolicyConfig != nil {
-			npc = !cluster.AddonsConfig.NetworkPolicyConfig.Disabled
-		}
-		newSpec.ClusterAddons.NetworkPolicyConfig = npc
-	}
+	newSpec.ClusterAddons = gke.BuildClusterAddons(cluster.AddonsConfig, cluster.VerticalPodAutoscaling)
 
 	if cluster.IpAllocationPolicy != nil {
 		newSpec.IPAllocationPolicy.ClusterIpv4CidrBlock = cluster.IpAllocationPolicy.ClusterIpv4CidrBlock
@@ -561,6 +937,9 @@ func (h *Handler) buildUpstreamClusterState(cluster *gkeapi.Cluster) (*gkev1.GKE
 		newSpec.IPAllocationPolicy.ServicesSecondaryRangeName = cluster.IpAllocationPolicy.ServicesSecondaryRangeName
 		newSpec.IPAllocationPolicy.SubnetworkName = cluster.IpAllocationPolicy.SubnetworkName
 		newSpec.IPAllocationPolicy.UseIPAliases = cluster.IpAllocationPolicy.UseIpAliases
+		newSpec.IPAllocationPolicy.StackType = cluster.IpAllocationPolicy.StackType
+		newSpec.IPAllocationPolicy.IPv6AccessType = cluster.IpAllocationPolicy.Ipv6AccessType
+		newSpec.IPAllocationPolicy.AdditionalPodRangesConfig = gke.BuildAdditionalPodRangesConfig(cluster.IpAllocationPolicy.AdditionalPodRangesConfig)
 	}
 
 	if cluster.MasterAuthorizedNetworksConfig != nil && cluster.MasterAuthorizedNetworksConfig.Enabled {
@@ -579,6 +958,14 @@ func (h *Handler) buildUpstreamClusterState(cluster *gkeapi.Cluster) (*gkev1.GKE
 	if cluster.MaintenancePolicy != nil && cluster.MaintenancePolicy.Window != nil && cluster.MaintenancePolicy.Window.DailyMaintenanceWindow != nil {
 		newSpec.MaintenanceWindow = &cluster.MaintenancePolicy.Window.DailyMaintenanceWindow.StartTime
 	}
+	newSpec.MaintenancePolicy = gke.BuildMaintenancePolicy(cluster.MaintenancePolicy)
+	newSpec.ClusterAutoscaling = gke.BuildClusterAutoscaling(cluster.Autoscaling)
+
+	releaseChannel := gke.ReleaseChannelUnspecified
+	if cluster.ReleaseChannel != nil && cluster.ReleaseChannel.Channel != "" {
+		releaseChannel = cluster.ReleaseChannel.Channel
+	}
+	newSpec.ReleaseChannel = &releaseChannel
 
 	if cluster.Autopilot != nil && cluster.Autopilot.Enabled {
 		newSpec.AutopilotConfig = &gkev1.GKEAutopilotConfig{
@@ -598,10 +985,12 @@ func (h *Handler) buildUpstreamClusterState(cluster *gkeapi.Cluster) (*gkev1.GKE
 			Name:             &np.Name,
 			Version:          &np.Version,
 			InitialNodeCount: &np.InitialNodeCount,
+			Locations:        np.Locations,
 		}
 
 		if np.Config != nil {
 			newNP.Config = &gkev1.GKENodeConfig{
+				Accelerators:   gke.BuildAccelerators(np.Config.Accelerators),
 				BootDiskKmsKey: np.Config.BootDiskKmsKey,
 				DiskSizeGb:     np.Config.DiskSizeGb,
 				DiskType:       np.Config.DiskType,
@@ -609,7 +998,10 @@ func (h *Handler) buildUpstreamClusterState(cluster *gkeapi.Cluster) (*gkev1.GKE
 				Labels:         np.Config.Labels,
 				LocalSsdCount:  np.Config.LocalSsdCount,
 				MachineType:    np.Config.MachineType,
+				OauthScopes:    np.Config.OauthScopes,
 				Preemptible:    np.Config.Preemptible,
+				ResourceLabels: np.Config.ResourceLabels,
+				Spot:           np.Config.Spot,
 				Tags:           np.Config.Tags,
 				ServiceAccount: np.Config.ServiceAccount,
 			}
@@ -622,13 +1014,16 @@ func (h *Handler) buildUpstreamClusterState(cluster *gkeapi.Cluster) (*gkev1.GKE
 					Value:  t.Value,
 				})
 			}
+			newNP.Config.WorkloadMetadataConfig = gke.BuildWorkloadMetadataConfig(np.Config.WorkloadMetadataConfig)
+			newNP.Config.ShieldedInstanceConfig = gke.BuildShieldedInstanceConfig(np.Config.ShieldedInstanceConfig)
 		}
 
 		if np.Autoscaling != nil {
 			newNP.Autoscaling = &gkev1.GKENodePoolAutoscaling{
-				Enabled:      np.Autoscaling.Enabled,
-				MaxNodeCount: np.Autoscaling.MaxNodeCount,
-				MinNodeCount: np.Autoscaling.MinNodeCount,
+				Enabled:         np.Autoscaling.Enabled,
+				MaxNodeCount:    np.Autoscaling.MaxNodeCount,
+				MinNodeCount:    np.Autoscaling.MinNodeCount,
+				Autoprovisioned: np.Autoscaling.Autoprovisioned,
 			}
 		}
 
@@ -642,6 +1037,9 @@ func (h *Handler) buildUpstreamClusterState(cluster *gkeapi.Cluster) (*gkev1.GKE
 		if np.MaxPodsConstraint != nil {
 			newNP.MaxPodsConstraint = &np.MaxPodsConstraint.MaxPodsPerNode
 		}
+
+		newNP.NetworkConfig = gke.BuildNodeNetworkConfig(np.NetworkConfig)
+		newNP.UpgradeSettings = gke.BuildUpgradeSettings(np.UpgradeSettings)
 		newSpec.NodePools = append(newSpec.NodePools, newNP)
 	}
 
@@ -649,18 +1047,32 @@ func (h *Handler) buildUpstreamClusterState(cluster *gkeapi.Cluster) (*gkev1.GKE
 }
 
 // createCASecret creates a secret containing a CA and endpoint for use in generating a kubeconfig file.
+// If the secret already exists, it is updated when the endpoints or CA of the cluster changed, e.g.
+// once the DNS endpoint is enabled or the IP endpoints are disabled.
 func (h *Handler) createCASecret(config *gkev1.GKEClusterConfig, cluster *gkeapi.Cluster) error {
 	var err error
 
-	if cluster.Endpoint == "" {
+	// Clusters with the IP endpoints disabled are only reachable through the DNS endpoint
+	dnsEndpoint := gke.DNSEndpoint(cluster)
+	endpoint := cluster.Endpoint
+	if endpoint == "" {
+		endpoint = dnsEndpoint
+	}
+	if endpoint == "" {
 		return fmt.Errorf("cluster [%s (id: %s)] has no endpoint", config.Spec.ClusterName, config.Name)
 	}
-	endpoint := cluster.Endpoint
 
 	if cluster.MasterAuth == nil || cluster.MasterAuth.ClusterCaCertificate == "" {
 		return fmt.Errorf("cluster [%s (id: %s)] has no CA", config.Spec.ClusterName, config.Name)
 	}
 	ca := []byte(cluster.MasterAuth.ClusterCaCertificate)
+	data := map[string][]byte{
+		"endpoint": []byte(endpoint),
+		"ca":       ca,
+	}
+	if dnsEndpoint != "" {
+		data["dnsEndpoint"] = []byte(dnsEndpoint)
+	}
 
 	_, err = h.secrets.Create(
 		&corev1.Secret{
@@ -676,15 +1088,23 @@ func (h *Handler) createCASecret(config *gkev1.GKEClusterConfig, cluster *gkeapi
 					},
 				},
 			},
-			Data: map[string][]byte{
-				"endpoint": []byte(endpoint),
-				"ca":       ca,
-			},
+			Data: data,
 		})
-	if errors.IsAlreadyExists(err) {
-		logrus.Debugf("CA secret [%s] already exists, ignoring", config.Name)
+	if !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	caSecret, err := h.secrets.Get(config.Namespace, config.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(caSecret.Data, data) {
 		return nil
 	}
+	logrus.Infof("Updating CA secret [%s] with the endpoints of cluster [%s (id: %s)]", config.Name, config.Spec.ClusterName, config.Name)
+	caSecret = caSecret.DeepCopy()
+	caSecret.Data = data
+	_, err = h.secrets.Update(caSecret)
 	return err
 }
 
@@ -2,6 +2,8 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -86,6 +88,71 @@ var _ = Describe("createCASecret", func() {
 		Expect(err.Error()).To(ContainSubstring("has no endpoint"))
 	})
 
+	It("should publish the DNS endpoint", func() {
+		clusterState.ControlPlaneEndpointsConfig = &gkeapi.ControlPlaneEndpointsConfig{
+			DnsEndpointConfig: &gkeapi.DNSEndpointConfig{
+				AllowExternalTraffic: true,
+				Endpoint:             "gke-test.us-east1.gke.goog",
+			},
+		}
+
+		err := handler.createCASecret(gkeConfig, clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(caSecret), caSecret)).To(Succeed())
+		Expect(caSecret.Data["endpoint"]).To(Equal([]byte("https://test.com")))
+		Expect(caSecret.Data["dnsEndpoint"]).To(Equal([]byte("gke-test.us-east1.gke.goog")))
+	})
+
+	It("should update the CA secret once the DNS endpoint is enabled on an existing cluster", func() {
+		err := handler.createCASecret(gkeConfig, clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(caSecret), caSecret)).To(Succeed())
+		Expect(caSecret.Data).NotTo(HaveKey("dnsEndpoint"))
+
+		clusterState.ControlPlaneEndpointsConfig = &gkeapi.ControlPlaneEndpointsConfig{
+			DnsEndpointConfig: &gkeapi.DNSEndpointConfig{
+				AllowExternalTraffic: true,
+				Endpoint:             "gke-test.us-east1.gke.goog",
+			},
+		}
+		err = handler.createCASecret(gkeConfig, clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(caSecret), caSecret)).To(Succeed())
+		Expect(caSecret.Data["endpoint"]).To(Equal([]byte("https://test.com")))
+		Expect(caSecret.Data["dnsEndpoint"]).To(Equal([]byte("gke-test.us-east1.gke.goog")))
+
+		// the IP endpoints are disabled, the DNS endpoint replaces the unreachable one
+		clusterState.Endpoint = ""
+		resourceVersion := caSecret.ResourceVersion
+		err = handler.createCASecret(gkeConfig, clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(caSecret), caSecret)).To(Succeed())
+		Expect(caSecret.Data["endpoint"]).To(Equal([]byte("gke-test.us-east1.gke.goog")))
+		Expect(caSecret.ResourceVersion).NotTo(Equal(resourceVersion))
+
+		// the secret is not updated when nothing changed
+		resourceVersion = caSecret.ResourceVersion
+		err = handler.createCASecret(gkeConfig, clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(caSecret), caSecret)).To(Succeed())
+		Expect(caSecret.ResourceVersion).To(Equal(resourceVersion))
+	})
+
+	It("should use the DNS endpoint if the IP endpoints are disabled", func() {
+		clusterState.Endpoint = ""
+		clusterState.ControlPlaneEndpointsConfig = &gkeapi.ControlPlaneEndpointsConfig{
+			DnsEndpointConfig: &gkeapi.DNSEndpointConfig{
+				AllowExternalTraffic: true,
+				Endpoint:             "gke-test.us-east1.gke.goog",
+			},
+		}
+
+		err := handler.createCASecret(gkeConfig, clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(caSecret), caSecret)).To(Succeed())
+		Expect(caSecret.Data["endpoint"]).To(Equal([]byte("gke-test.us-east1.gke.goog")))
+	})
+
 	It("should return error if cluster CA doesn't exist", func() {
 		clusterState.MasterAuth = nil
 
@@ -204,9 +271,15 @@ var _ = Describe("buildUpstreamClusterState", func() {
 		Expect(upstreamSpec.PrivateClusterConfig.EnablePrivateEndpoint).To(Equal(clusterState.PrivateClusterConfig.EnablePrivateEndpoint))
 		Expect(upstreamSpec.PrivateClusterConfig.EnablePrivateNodes).To(Equal(clusterState.PrivateClusterConfig.EnablePrivateNodes))
 		Expect(upstreamSpec.PrivateClusterConfig.MasterIpv4CidrBlock).To(Equal(clusterState.PrivateClusterConfig.MasterIpv4CidrBlock))
+		Expect(upstreamSpec.MaintenancePolicy).To(Equal(&gkev1.GKEMaintenancePolicy{}))
+		Expect(upstreamSpec.ClusterAutoscaling).To(Equal(&gkev1.GKEClusterAutoscaling{}))
 		Expect(upstreamSpec.ClusterAddons.HTTPLoadBalancing).To(Equal(!clusterState.AddonsConfig.HttpLoadBalancing.Disabled))
 		Expect(upstreamSpec.ClusterAddons.HorizontalPodAutoscaling).To(Equal(!clusterState.AddonsConfig.HorizontalPodAutoscaling.Disabled))
 		Expect(upstreamSpec.ClusterAddons.NetworkPolicyConfig).To(Equal(!clusterState.AddonsConfig.NetworkPolicyConfig.Disabled))
+		Expect(*upstreamSpec.ClusterAddons.GcePersistentDiskCsiDriver).To(BeFalse())
+		Expect(*upstreamSpec.ClusterAddons.VerticalPodAutoscaling).To(BeFalse())
+		Expect(*upstreamSpec.DatapathProvider).To(BeEmpty())
+		Expect(upstreamSpec.DNSConfig).To(Equal(&gkev1.GKEDNSConfig{}))
 		Expect(upstreamSpec.NodePools).To(HaveLen(1))
 		Expect(*upstreamSpec.NodePools[0].Name).To(Equal(clusterState.NodePools[0].Name))
 		Expect(*upstreamSpec.NodePools[0].InitialNodeCount).To(Equal(clusterState.NodePools[0].InitialNodeCount))
@@ -222,6 +295,334 @@ var _ = Describe("buildUpstreamClusterState", func() {
 		Expect(upstreamSpec.NodePools[0].Autoscaling.MaxNodeCount).To(Equal(clusterState.NodePools[0].Autoscaling.MaxNodeCount))
 		Expect(upstreamSpec.NodePools[0].Management.AutoRepair).To(Equal(clusterState.NodePools[0].Management.AutoRepair))
 		Expect(upstreamSpec.NodePools[0].Management.AutoUpgrade).To(Equal(clusterState.NodePools[0].Management.AutoUpgrade))
+		Expect(*upstreamSpec.ReleaseChannel).To(Equal(gke.ReleaseChannelUnspecified))
+	})
+
+	It("should build upstream release channel", func() {
+		clusterState.ReleaseChannel = &gkeapi.ReleaseChannel{
+			Channel: gke.ReleaseChannelStable,
+		}
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*upstreamSpec.ReleaseChannel).To(Equal(gke.ReleaseChannelStable))
+	})
+
+	It("should build upstream node pool taints, tags, resource labels and spot", func() {
+		clusterState.NodePools[0].Config.Taints = []*gkeapi.NodeTaint{
+			{Effect: gke.TaintEffectNoSchedule, Key: "group", Value: "examples"},
+		}
+		clusterState.NodePools[0].Config.Tags = []string{"red", "blue"}
+		clusterState.NodePools[0].Config.ResourceLabels = map[string]string{"team": "data"}
+		clusterState.NodePools[0].Config.Spot = true
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.NodePools[0].Config.Taints).To(Equal([]gkev1.GKENodeTaintConfig{
+			{Effect: gke.TaintEffectNoSchedule, Key: "group", Value: "examples"},
+		}))
+		Expect(upstreamSpec.NodePools[0].Config.Tags).To(Equal([]string{"red", "blue"}))
+		Expect(upstreamSpec.NodePools[0].Config.ResourceLabels).To(Equal(map[string]string{"team": "data"}))
+		Expect(upstreamSpec.NodePools[0].Config.Spot).To(BeTrue())
+	})
+
+	It("should build upstream node pool locations", func() {
+		clusterState.NodePools[0].Locations = []string{"test-east1-a"}
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.NodePools[0].Locations).To(Equal([]string{"test-east1-a"}))
+	})
+
+	It("should build upstream node pool accelerators", func() {
+		clusterState.NodePools[0].Config.Accelerators = []*gkeapi.AcceleratorConfig{
+			{
+				AcceleratorCount: 1,
+				AcceleratorType:  "nvidia-tesla-t4",
+				GpuDriverInstallationConfig: &gkeapi.GPUDriverInstallationConfig{
+					GpuDriverVersion: gke.GPUDriverVersionDefault,
+				},
+			},
+		}
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.NodePools[0].Config.Accelerators).To(Equal([]gkev1.GKEAcceleratorConfig{
+			{
+				AcceleratorCount: 1,
+				AcceleratorType:  "nvidia-tesla-t4",
+				GpuDriverInstallationConfig: &gkev1.GKEGPUDriverInstallationConfig{
+					GpuDriverVersion: gke.GPUDriverVersionDefault,
+				},
+			},
+		}))
+	})
+
+	It("should build upstream node pool upgrade settings", func() {
+		clusterState.NodePools[0].UpgradeSettings = &gkeapi.UpgradeSettings{
+			Strategy: gke.UpgradeStrategyBlueGreen,
+			BlueGreenSettings: &gkeapi.BlueGreenSettings{
+				NodePoolSoakDuration: "3600s",
+				StandardRolloutPolicy: &gkeapi.StandardRolloutPolicy{
+					BatchPercentage:   0.25,
+					BatchSoakDuration: "300s",
+				},
+			},
+		}
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.NodePools[0].UpgradeSettings).To(Equal(&gkev1.GKENodePoolUpgradeSettings{
+			Strategy: gke.UpgradeStrategyBlueGreen,
+			BlueGreenSettings: &gkev1.GKEBlueGreenSettings{
+				NodePoolSoakDuration: "3600s",
+				StandardRolloutPolicy: &gkev1.GKEStandardRolloutPolicy{
+					BatchPercentage:   0.25,
+					BatchSoakDuration: "300s",
+				},
+			},
+		}))
+	})
+
+	It("should build upstream cluster autoscaling", func() {
+		clusterState.Autoscaling = &gkeapi.ClusterAutoscaling{
+			EnableNodeAutoprovisioning: true,
+			AutoscalingProfile:         gke.AutoscalingProfileOptimizeUtilization,
+			ResourceLimits: []*gkeapi.ResourceLimit{
+				{ResourceType: gke.ResourceLimitCPU, Maximum: 32},
+				{ResourceType: gke.ResourceLimitMemory, Maximum: 128},
+			},
+		}
+		clusterState.NodePools = append(clusterState.NodePools, &gkeapi.NodePool{
+			Name: "nap-n1-standard-4",
+			Autoscaling: &gkeapi.NodePoolAutoscaling{
+				Enabled:         true,
+				Autoprovisioned: true,
+				MaxNodeCount:    1000,
+			},
+		})
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.ClusterAutoscaling).To(Equal(&gkev1.GKEClusterAutoscaling{
+			EnableNodeAutoprovisioning: true,
+			AutoscalingProfile:         gke.AutoscalingProfileOptimizeUtilization,
+			ResourceLimits: []gkev1.GKEResourceLimit{
+				{ResourceType: gke.ResourceLimitCPU, Maximum: 32},
+				{ResourceType: gke.ResourceLimitMemory, Maximum: 128},
+			},
+		}))
+		Expect(upstreamSpec.NodePools).To(HaveLen(2))
+		Expect(upstreamSpec.NodePools[0].Autoscaling.Autoprovisioned).To(BeFalse())
+		Expect(upstreamSpec.NodePools[1].Autoscaling.Autoprovisioned).To(BeTrue())
+	})
+
+	It("should build upstream datapath provider and DNS config", func() {
+		clusterState.NetworkConfig = &gkeapi.NetworkConfig{
+			DatapathProvider: gke.DatapathProviderAdvanced,
+			DnsConfig: &gkeapi.DNSConfig{
+				ClusterDns:      gke.ClusterDNSCloudDNS,
+				ClusterDnsScope: gke.ClusterDNSScopeCluster,
+			},
+		}
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*upstreamSpec.DatapathProvider).To(Equal(gke.DatapathProviderAdvanced))
+		Expect(upstreamSpec.DNSConfig).To(Equal(&gkev1.GKEDNSConfig{
+			ClusterDNS:      gke.ClusterDNSCloudDNS,
+			ClusterDNSScope: gke.ClusterDNSScopeCluster,
+		}))
+	})
+
+	It("should build upstream stack type", func() {
+		clusterState.IpAllocationPolicy = &gkeapi.IPAllocationPolicy{
+			UseIpAliases:   true,
+			StackType:      gke.StackTypeIPv4IPv6,
+			Ipv6AccessType: gke.IPv6AccessTypeExternal,
+		}
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.IPAllocationPolicy.StackType).To(Equal(gke.StackTypeIPv4IPv6))
+		Expect(upstreamSpec.IPAllocationPolicy.IPv6AccessType).To(Equal(gke.IPv6AccessTypeExternal))
+	})
+
+	It("should build upstream private cluster config", func() {
+		clusterState.PrivateClusterConfig = &gkeapi.PrivateClusterConfig{
+			EnablePrivateNodes:        true,
+			PrivateEndpointSubnetwork: "control-plane-subnetwork",
+			MasterGlobalAccessConfig: &gkeapi.PrivateClusterMasterGlobalAccessConfig{
+				Enabled: true,
+			},
+		}
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.PrivateClusterConfig.PrivateEndpointSubnetwork).To(Equal("control-plane-subnetwork"))
+		Expect(upstreamSpec.PrivateClusterConfig.MasterGlobalAccessConfig.Enabled).To(BeTrue())
+
+		clusterState.PrivateClusterConfig = nil
+		upstreamSpec, err = handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.PrivateClusterConfig.EnablePrivateNodes).To(BeFalse())
+		Expect(upstreamSpec.PrivateClusterConfig.MasterGlobalAccessConfig.Enabled).To(BeFalse())
+	})
+
+	It("should build upstream control plane endpoints", func() {
+		clusterState.ControlPlaneEndpointsConfig = &gkeapi.ControlPlaneEndpointsConfig{
+			DnsEndpointConfig: &gkeapi.DNSEndpointConfig{
+				AllowExternalTraffic: true,
+				Endpoint:             "gke-test.us-east1.gke.goog",
+			},
+			IpEndpointsConfig: &gkeapi.IPEndpointsConfig{
+				Enabled: true,
+				AuthorizedNetworksConfig: &gkeapi.MasterAuthorizedNetworksConfig{
+					Enabled: true,
+					CidrBlocks: []*gkeapi.CidrBlock{
+						{CidrBlock: "10.0.0.0/8", DisplayName: "internal"},
+					},
+				},
+			},
+		}
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.ControlPlaneEndpointsConfig.DNSEndpointConfig.AllowExternalTraffic).To(BeTrue())
+		Expect(*upstreamSpec.ControlPlaneEndpointsConfig.IPEndpointsConfig.Enabled).To(BeTrue())
+		Expect(*upstreamSpec.ControlPlaneEndpointsConfig.IPEndpointsConfig.GlobalAccess).To(BeFalse())
+		Expect(upstreamSpec.ControlPlaneEndpointsConfig.IPEndpointsConfig.AuthorizedNetworksConfig).To(Equal(&gkev1.GKEMasterAuthorizedNetworksConfig{
+			Enabled: true,
+			CidrBlocks: []*gkev1.GKECidrBlock{
+				{CidrBlock: "10.0.0.0/8", DisplayName: "internal"},
+			},
+		}))
+	})
+
+	It("should build upstream database encryption", func() {
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.DatabaseEncryption).To(Equal(&gkev1.GKEDatabaseEncryption{
+			State: gke.DatabaseEncryptionDecrypted,
+		}))
+
+		clusterState.DatabaseEncryption = &gkeapi.DatabaseEncryption{
+			State:        gke.DatabaseEncryptionEncrypted,
+			KeyName:      "projects/test-project/locations/us-east1/keyRings/test-keyring/cryptoKeys/test-key",
+			CurrentState: "CURRENT_STATE_ENCRYPTION_PENDING",
+		}
+		upstreamSpec, err = handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.DatabaseEncryption).To(Equal(&gkev1.GKEDatabaseEncryption{
+			State:        gke.DatabaseEncryptionEncrypted,
+			KeyName:      "projects/test-project/locations/us-east1/keyRings/test-keyring/cryptoKeys/test-key",
+			CurrentState: "CURRENT_STATE_ENCRYPTION_PENDING",
+		}))
+	})
+
+	It("should build upstream workload identity", func() {
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.WorkloadIdentityConfig).To(Equal(&gkev1.GKEWorkloadIdentityConfig{}))
+
+		clusterState.WorkloadIdentityConfig = &gkeapi.WorkloadIdentityConfig{
+			WorkloadPool: "test-project.svc.id.goog",
+		}
+		clusterState.NodePools[0].Config.WorkloadMetadataConfig = &gkeapi.WorkloadMetadataConfig{
+			Mode: gke.WorkloadMetadataModeGKE,
+		}
+		upstreamSpec, err = handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.WorkloadIdentityConfig.WorkloadPool).To(Equal("test-project.svc.id.goog"))
+		Expect(upstreamSpec.NodePools[0].Config.WorkloadMetadataConfig.Mode).To(Equal(gke.WorkloadMetadataModeGKE))
+	})
+
+	It("should build upstream shielded nodes", func() {
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.ShieldedNodes).To(Equal(&gkev1.GKEShieldedNodes{}))
+		Expect(upstreamSpec.NodePools[0].Config.ShieldedInstanceConfig).To(BeNil())
+
+		clusterState.ShieldedNodes = &gkeapi.ShieldedNodes{Enabled: true}
+		clusterState.NodePools[0].Config.ShieldedInstanceConfig = &gkeapi.ShieldedInstanceConfig{
+			EnableIntegrityMonitoring: true,
+			EnableSecureBoot:          true,
+		}
+		upstreamSpec, err = handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.ShieldedNodes.Enabled).To(BeTrue())
+		Expect(upstreamSpec.NodePools[0].Config.ShieldedInstanceConfig).To(Equal(&gkev1.GKEShieldedInstanceConfig{
+			EnableIntegrityMonitoring: true,
+			EnableSecureBoot:          true,
+		}))
+	})
+
+	It("should build upstream pod ranges", func() {
+		clusterState.IpAllocationPolicy = &gkeapi.IPAllocationPolicy{
+			UseIpAliases: true,
+			AdditionalPodRangesConfig: &gkeapi.AdditionalPodRangesConfig{
+				PodRangeNames: []string{"pods-2"},
+			},
+		}
+		clusterState.NodePools[0].NetworkConfig = &gkeapi.NodeNetworkConfig{
+			PodRange:         "pods-2",
+			PodIpv4CidrBlock: "10.8.0.0/14",
+		}
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.IPAllocationPolicy.AdditionalPodRangesConfig.PodRangeNames).To(Equal([]string{"pods-2"}))
+		Expect(upstreamSpec.NodePools[0].NetworkConfig.PodRange).To(Equal("pods-2"))
+		Expect(upstreamSpec.NodePools[0].NetworkConfig.PodIpv4CidrBlock).To(Equal("10.8.0.0/14"))
+		Expect(*upstreamSpec.NodePools[0].NetworkConfig.EnablePrivateNodes).To(BeFalse())
+	})
+
+	It("should build upstream cluster addons", func() {
+		clusterState.AddonsConfig.HttpLoadBalancing.Disabled = true
+		clusterState.AddonsConfig.GcePersistentDiskCsiDriverConfig = &gkeapi.GcePersistentDiskCsiDriverConfig{Enabled: true}
+		clusterState.AddonsConfig.DnsCacheConfig = &gkeapi.DnsCacheConfig{Enabled: true}
+		clusterState.AddonsConfig.GkeBackupAgentConfig = &gkeapi.GkeBackupAgentConfig{Enabled: false}
+		clusterState.VerticalPodAutoscaling = &gkeapi.VerticalPodAutoscaling{Enabled: true}
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(upstreamSpec.ClusterAddons.HTTPLoadBalancing).To(BeFalse())
+		Expect(*upstreamSpec.ClusterAddons.GcePersistentDiskCsiDriver).To(BeTrue())
+		Expect(*upstreamSpec.ClusterAddons.GcpFilestoreCsiDriver).To(BeFalse())
+		Expect(*upstreamSpec.ClusterAddons.DNSCacheConfig).To(BeTrue())
+		Expect(*upstreamSpec.ClusterAddons.GkeBackupAgentConfig).To(BeFalse())
+		Expect(*upstreamSpec.ClusterAddons.VerticalPodAutoscaling).To(BeTrue())
+	})
+
+	It("should build upstream maintenance policy", func() {
+		clusterState.MaintenancePolicy = &gkeapi.MaintenancePolicy{
+			Window: &gkeapi.MaintenanceWindow{
+				RecurringWindow: &gkeapi.RecurringTimeWindow{
+					Recurrence: "FREQ=WEEKLY;BYDAY=SA,SU",
+					Window: &gkeapi.TimeWindow{
+						StartTime: "2024-01-06T02:00:00Z",
+						EndTime:   "2024-01-06T08:00:00Z",
+					},
+				},
+				MaintenanceExclusions: map[string]gkeapi.TimeWindow{
+					"holiday-freeze": {
+						StartTime: "2024-12-20T00:00:00Z",
+						EndTime:   "2025-01-05T00:00:00Z",
+						MaintenanceExclusionOptions: &gkeapi.MaintenanceExclusionOptions{
+							Scope: gke.MaintenanceExclusionNoMinorUpgrades,
+						},
+					},
+				},
+			},
+			ResourceVersion: "abc123",
+		}
+		upstreamSpec, err := handler.buildUpstreamClusterState(clusterState)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*upstreamSpec.MaintenanceWindow).To(BeEmpty())
+		Expect(upstreamSpec.MaintenancePolicy).To(Equal(&gkev1.GKEMaintenancePolicy{
+			RecurringWindow: &gkev1.GKERecurringTimeWindow{
+				StartTime:  "2024-01-06T02:00:00Z",
+				EndTime:    "2024-01-06T08:00:00Z",
+				Recurrence: "FREQ=WEEKLY;BYDAY=SA,SU",
+			},
+			MaintenanceExclusions: []gkev1.GKEMaintenanceExclusion{
+				{
+					Name:      "holiday-freeze",
+					StartTime: "2024-12-20T00:00:00Z",
+					EndTime:   "2025-01-05T00:00:00Z",
+					Scope:     gke.MaintenanceExclusionNoMinorUpgrades,
+				},
+			},
+			ResourceVersion: "abc123",
+		}))
 	})
 })
 
@@ -372,8 +773,6 @@ var _ = Describe("importCluster", func() {
 			gkeCC:        gkeFactory.Gke().V1().GKEClusterConfig(),
 			secrets:      coreFactory.Core().V1().Secret(),
 			secretsCache: coreFactory.Core().V1().Secret().Cache(),
-			gkeClient:    gkeServiceMock,
-			gkeClientCtx: context.Background(),
 		}
 	})
 
@@ -389,9 +788,11 @@ var _ = Describe("importCluster", func() {
 					gkeConfig.Spec.ClusterName)).
 			Return(clusterState, nil)
 
-		gotGKEConfig, err := handler.importCluster(gkeConfig)
+		gotGKEConfig, err := handler.importCluster(ctx, gkeServiceMock, gkeConfig)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(gotGKEConfig.Status.Phase).To(Equal(gkeConfigActivePhase))
+		Expect(gkev1.ClusterConditionReady.IsTrue(gotGKEConfig)).To(BeTrue())
+		Expect(gotGKEConfig.Status.ObservedGeneration).To(Equal(gotGKEConfig.Generation))
 		Expect(cl.Get(ctx, client.ObjectKeyFromObject(caSecret), caSecret)).To(Succeed())
 		Expect(caSecret.OwnerReferences).To(HaveLen(1))
 		Expect(caSecret.OwnerReferences[0].Name).To(Equal(gotGKEConfig.Name))
@@ -410,7 +811,7 @@ var _ = Describe("importCluster", func() {
 					gkeConfig.Spec.ClusterName)).
 			Return(&gkeapi.Cluster{}, nil)
 
-		gotGKEConfig, err := handler.importCluster(gkeConfig)
+		gotGKEConfig, err := handler.importCluster(ctx, gkeServiceMock, gkeConfig)
 		Expect(err).To(HaveOccurred())
 		Expect(gotGKEConfig).NotTo(BeNil())
 	})
@@ -603,8 +1004,6 @@ var _ = Describe("createCluster", func() {
 			gkeCC:        gkeFactory.Gke().V1().GKEClusterConfig(),
 			secrets:      coreFactory.Core().V1().Secret(),
 			secretsCache: coreFactory.Core().V1().Secret().Cache(),
-			gkeClient:    gkeServiceMock,
-			gkeClientCtx: context.Background(),
 		}
 	})
 
@@ -627,9 +1026,11 @@ var _ = Describe("createCluster", func() {
 				gke.LocationRRN(gkeConfig.Spec.ProjectID, gke.Location(gkeConfig.Spec.Region, gkeConfig.Spec.Zone))).
 			Return(&gkeapi.ListClustersResponse{}, nil)
 
-		gotGKEConfig, err := handler.create(gkeConfig)
+		gotGKEConfig, err := handler.create(ctx, gkeServiceMock, gkeConfig)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(gotGKEConfig.Status.Phase).To(Equal(gkeConfigCreatingPhase))
+		Expect(gkev1.ClusterConditionProvisioning.IsTrue(gotGKEConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionReady.IsFalse(gotGKEConfig)).To(BeTrue())
 	})
 
 	It("should return error if cluster already exist", func() {
@@ -643,7 +1044,7 @@ var _ = Describe("createCluster", func() {
 				Clusters: []*gkeapi.Cluster{clusterState},
 			}, nil)
 
-		gotGKEConfig, err := handler.create(gkeConfig)
+		gotGKEConfig, err := handler.create(ctx, gkeServiceMock, gkeConfig)
 		Expect(err).To(HaveOccurred())
 		Expect(gotGKEConfig).NotTo(BeNil())
 	})
@@ -665,7 +1066,7 @@ var _ = Describe("createCluster", func() {
 			ccr,
 		)
 
-		gotGKEConfig, err := handler.create(gkeConfig)
+		gotGKEConfig, err := handler.create(ctx, gkeServiceMock, gkeConfig)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(gotGKEConfig).NotTo(BeNil())
 	})
@@ -687,7 +1088,7 @@ var _ = Describe("createCluster", func() {
 			ccr,
 		)
 
-		gotGKEConfig, err := handler.create(gkeConfig)
+		gotGKEConfig, err := handler.create(ctx, gkeServiceMock, gkeConfig)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(gotGKEConfig).NotTo(BeNil())
 	})
@@ -709,7 +1110,7 @@ var _ = Describe("createCluster", func() {
 			ccr,
 		)
 
-		gotGKEConfig, err := handler.create(gkeConfig)
+		gotGKEConfig, err := handler.create(ctx, gkeServiceMock, gkeConfig)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(gotGKEConfig).NotTo(BeNil())
 	})
@@ -748,7 +1149,7 @@ var _ = Describe("createCluster", func() {
 			ccr,
 		)
 
-		gotGKEConfig, err := handler.create(gkeConfig)
+		gotGKEConfig, err := handler.create(ctx, gkeServiceMock, gkeConfig)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(gotGKEConfig).NotTo(BeNil())
 	})
@@ -761,8 +1162,348 @@ var _ = Describe("createCluster", func() {
 			gke.LocationRRN(gkeConfig.Spec.ProjectID, gke.Location(gkeConfig.Spec.Region, gkeConfig.Spec.Zone))).
 			Return(&gkeapi.ListClustersResponse{}, nil)
 
-		_, err := handler.create(gkeConfig)
+		_, err := handler.create(ctx, gkeServiceMock, gkeConfig)
 		Expect(err).To(HaveOccurred())
 		Expect(err.Error()).To(Equal("field [serviceAccount] must either be an empty string, 'default' or set to a valid email address for nodepool [test-node-pool] in non-nil cluster [test-cluster (id: test-cluster)]"))
 	})
 })
+
+var _ = Describe("operations", func() {
+	var (
+		handler         *Handler
+		mockController  *gomock.Controller
+		gkeServiceMock  *mock_services.MockGKEClusterService
+		gkeConfig       *gkev1.GKEClusterConfig
+		clusterState    *gkeapi.Cluster
+		enqueuedAfter   int
+		operationRRN    string
+		operationStatus *gkev1.GKEOperation
+	)
+
+	BeforeEach(func() {
+		enqueuedAfter = 0
+		mockController = gomock.NewController(GinkgoT())
+		gkeServiceMock = mock_services.NewMockGKEClusterService(mockController)
+
+		gkeConfig = &gkev1.GKEClusterConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-operations",
+				Namespace: "default",
+			},
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName: "test-cluster",
+				ProjectID:   "example-project-name",
+				Region:      "us-east1",
+			},
+		}
+		Expect(cl.Create(ctx, gkeConfig)).To(Succeed())
+
+		clusterState = &gkeapi.Cluster{
+			Name:   "test-cluster",
+			Status: ClusterStatusReconciling,
+		}
+
+		operationRRN = gke.OperationRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region, "operation-1")
+		operationStatus = &gkev1.GKEOperation{
+			Name:          "operation-1",
+			OperationType: "UPDATE_CLUSTER",
+			Status:        "RUNNING",
+		}
+
+		handler = &Handler{
+			gkeCC:        gkeFactory.Gke().V1().GKEClusterConfig(),
+			secrets:      coreFactory.Core().V1().Secret(),
+			secretsCache: coreFactory.Core().V1().Secret().Cache(),
+			gkeEnqueueAfter: func(_, _ string, _ time.Duration) {
+				enqueuedAfter++
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(test.CleanupAndWait(ctx, cl, gkeConfig)).To(Succeed())
+		mockController.Finish()
+	})
+
+	withPendingOperation := func() {
+		gkeConfig.Status.Phase = gkeConfigUpdatingPhase
+		gkeConfig.Status.PendingOperation = operationStatus
+		var err error
+		gkeConfig, err = handler.gkeCC.UpdateStatus(gkeConfig)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("should track the pending operation of a reconciling cluster", func() {
+		gkeServiceMock.EXPECT().
+			ClusterGet(ctx, gke.ClusterRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region, gkeConfig.Spec.ClusterName)).
+			Return(clusterState, nil)
+		gkeServiceMock.EXPECT().
+			OperationList(ctx, gke.LocationRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region)).
+			Return(&gkeapi.ListOperationsResponse{
+				Operations: []*gkeapi.Operation{
+					{
+						Name:          "operation-1",
+						OperationType: "UPGRADE_MASTER",
+						Status:        "RUNNING",
+						TargetLink:    "https://container.googleapis.com/v1/projects/123/locations/us-east1/clusters/test-cluster",
+					},
+				},
+			}, nil)
+
+		gotGKEConfig, err := handler.checkAndUpdate(ctx, gkeServiceMock, gkeConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.Phase).To(Equal(gkeConfigUpdatingPhase))
+		Expect(gotGKEConfig.Status.PendingOperation).NotTo(BeNil())
+		Expect(gotGKEConfig.Status.PendingOperation.Name).To(Equal("operation-1"))
+		Expect(gotGKEConfig.Status.PendingOperation.OperationType).To(Equal("UPGRADE_MASTER"))
+		Expect(gkev1.ClusterConditionUpdating.GetReason(gotGKEConfig)).To(Equal(reasonClusterReconciling))
+	})
+
+	It("should wait for a reconciling cluster without a pending operation", func() {
+		gkeServiceMock.EXPECT().
+			ClusterGet(ctx, gke.ClusterRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region, gkeConfig.Spec.ClusterName)).
+			Return(clusterState, nil)
+		gkeServiceMock.EXPECT().
+			OperationList(ctx, gke.LocationRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region)).
+			Return(&gkeapi.ListOperationsResponse{}, nil)
+
+		gotGKEConfig, err := handler.checkAndUpdate(ctx, gkeServiceMock, gkeConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.Phase).To(Equal(gkeConfigUpdatingPhase))
+		Expect(gotGKEConfig.Status.PendingOperation).To(BeNil())
+	})
+
+	It("should return error if listing operations fails", func() {
+		gkeServiceMock.EXPECT().
+			ClusterGet(ctx, gke.ClusterRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region, gkeConfig.Spec.ClusterName)).
+			Return(clusterState, nil)
+		gkeServiceMock.EXPECT().
+			OperationList(ctx, gke.LocationRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region)).
+			Return(nil, errors.New("googleapi: Error 403: Permission denied, forbidden"))
+
+		gotGKEConfig, err := handler.checkAndUpdate(ctx, gkeServiceMock, gkeConfig)
+		Expect(err).To(HaveOccurred())
+		Expect(gotGKEConfig.Status.PendingOperation).To(BeNil())
+	})
+
+	It("should enqueue the config while the pending operation is running", func() {
+		withPendingOperation()
+		gkeServiceMock.EXPECT().
+			OperationGet(ctx, operationRRN).
+			Return(&gkeapi.Operation{
+				Name:          "operation-1",
+				OperationType: "UPDATE_CLUSTER",
+				Status:        "RUNNING",
+			}, nil)
+
+		gotGKEConfig, err := handler.checkAndUpdate(ctx, gkeServiceMock, gkeConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.PendingOperation).To(Equal(operationStatus))
+		Expect(enqueuedAfter).To(Equal(1))
+	})
+
+	It("should record the progress of the pending operation", func() {
+		withPendingOperation()
+		gkeServiceMock.EXPECT().
+			OperationGet(ctx, operationRRN).
+			Return(&gkeapi.Operation{
+				Name:          "operation-1",
+				OperationType: "UPDATE_CLUSTER",
+				Status:        "RUNNING",
+				Progress: &gkeapi.OperationProgress{
+					Metrics: []*gkeapi.Metric{
+						{Name: "NODES_TOTAL", IntValue: 3},
+						{Name: "NODES_DONE", IntValue: 2},
+					},
+				},
+			}, nil)
+
+		gotGKEConfig, err := handler.checkAndUpdate(ctx, gkeServiceMock, gkeConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.PendingOperation.Progress).To(Equal("2/3 nodes"))
+	})
+
+	It("should clear the pending operation once it is done", func() {
+		withPendingOperation()
+		gkeServiceMock.EXPECT().
+			OperationGet(ctx, operationRRN).
+			Return(&gkeapi.Operation{
+				Name:          "operation-1",
+				OperationType: "UPDATE_CLUSTER",
+				Status:        gke.OperationStatusDone,
+			}, nil)
+
+		gotGKEConfig, done, err := handler.waitForPendingOperation(ctx, gkeServiceMock, gkeConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(done).To(BeTrue())
+		Expect(gotGKEConfig.Status.PendingOperation).To(BeNil())
+	})
+
+	It("should clear the pending operation if it no longer exists", func() {
+		withPendingOperation()
+		gkeServiceMock.EXPECT().
+			OperationGet(ctx, operationRRN).
+			Return(nil, errors.New("googleapi: Error 404: Not found: operation-1., notFound"))
+
+		gotGKEConfig, done, err := handler.waitForPendingOperation(ctx, gkeServiceMock, gkeConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(done).To(BeTrue())
+		Expect(gotGKEConfig.Status.PendingOperation).To(BeNil())
+	})
+
+	It("should record the error of a failed operation as the failure message", func() {
+		withPendingOperation()
+		gkeServiceMock.EXPECT().
+			OperationGet(ctx, operationRRN).
+			Return(&gkeapi.Operation{
+				Name:          "operation-1",
+				OperationType: "UPDATE_CLUSTER",
+				Status:        gke.OperationStatusDone,
+				Error:         &gkeapi.Status{Code: 8, Message: "Insufficient regional quota"},
+			}, nil)
+
+		onChange := handler.recordError(func(_ string, config *gkev1.GKEClusterConfig) (*gkev1.GKEClusterConfig, error) {
+			return handler.checkAndUpdate(ctx, gkeServiceMock, config)
+		})
+		gotGKEConfig, err := onChange("", gkeConfig)
+		Expect(err).To(HaveOccurred())
+		Expect(gotGKEConfig.Status.PendingOperation).To(BeNil())
+		Expect(gotGKEConfig.Status.FailureMessage).To(Equal("operation operation-1 [UPDATE_CLUSTER] failed: Insufficient regional quota"))
+		Expect(gkev1.ClusterConditionDegraded.IsTrue(gotGKEConfig)).To(BeTrue())
+	})
+})
+
+var _ = Describe("updateUpstreamClusterState", func() {
+	var (
+		handler        *Handler
+		mockController *gomock.Controller
+		gkeServiceMock *mock_services.MockGKEClusterService
+		gkeConfig      *gkev1.GKEClusterConfig
+		upstreamSpec   *gkev1.GKEClusterConfigSpec
+		orphanName     = "orphan-pool"
+		napName        = "nap-n1-standard-4"
+		enqueuedAfter  int
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		gkeServiceMock = mock_services.NewMockGKEClusterService(mockController)
+
+		gkeConfig = &gkev1.GKEClusterConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-update-upstream",
+				Namespace: "default",
+			},
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName: "test-cluster",
+				ProjectID:   "example-project-name",
+				Region:      "us-east1",
+				NodePools:   []gkev1.GKENodePoolConfig{},
+			},
+		}
+		Expect(cl.Create(ctx, gkeConfig)).To(Succeed())
+
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			NodePools: []gkev1.GKENodePoolConfig{
+				{
+					Name: &napName,
+					Autoscaling: &gkev1.GKENodePoolAutoscaling{
+						Enabled:         true,
+						Autoprovisioned: true,
+					},
+				},
+				{
+					Name:        &orphanName,
+					Autoscaling: &gkev1.GKENodePoolAutoscaling{},
+				},
+			},
+		}
+
+		handler = &Handler{
+			gkeCC:        gkeFactory.Gke().V1().GKEClusterConfig(),
+			secrets:      coreFactory.Core().V1().Secret(),
+			secretsCache: coreFactory.Core().V1().Secret().Cache(),
+			gkeEnqueue:   func(_, _ string) {},
+			gkeEnqueueAfter: func(_, _ string, _ time.Duration) {
+				enqueuedAfter++
+			},
+		}
+		enqueuedAfter = 0
+	})
+
+	AfterEach(func() {
+		Expect(test.CleanupAndWait(ctx, cl, gkeConfig)).To(Succeed())
+		mockController.Finish()
+	})
+
+	It("should record that the secrets are being re-encrypted", func() {
+		upstreamSpec.DatabaseEncryption = &gkev1.GKEDatabaseEncryption{
+			State:        gke.DatabaseEncryptionEncrypted,
+			CurrentState: "CURRENT_STATE_ENCRYPTION_PENDING",
+		}
+
+		gotGKEConfig, err := handler.updateUpstreamClusterState(ctx, gkeServiceMock, gkeConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.Phase).To(Equal(gkeConfigUpdatingPhase))
+		Expect(gkev1.ClusterConditionUpdating.IsTrue(gotGKEConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionUpdating.GetReason(gotGKEConfig)).To(Equal(stepDatabaseEncryption))
+		Expect(gkev1.ClusterConditionUpdating.GetMessage(gotGKEConfig)).To(Equal("waiting for the secrets to be re-encrypted, current state CURRENT_STATE_ENCRYPTION_PENDING"))
+		Expect(gkev1.ClusterConditionReady.IsFalse(gotGKEConfig)).To(BeTrue())
+		Expect(enqueuedAfter).To(Equal(1))
+
+		// the status is not written again while the re-encryption is pending
+		resourceVersion := gotGKEConfig.ResourceVersion
+		gotGKEConfig, err = handler.updateUpstreamClusterState(ctx, gkeServiceMock, gotGKEConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.ResourceVersion).To(Equal(resourceVersion))
+		Expect(enqueuedAfter).To(Equal(2))
+	})
+
+	It("should raise the SecurityWarning condition of a cluster with shielded nodes disabled upstream", func() {
+		upstreamSpec.NodePools = upstreamSpec.NodePools[:1]
+		upstreamSpec.ShieldedNodes = &gkev1.GKEShieldedNodes{}
+
+		gotGKEConfig, err := handler.updateUpstreamClusterState(ctx, gkeServiceMock, gkeConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gkev1.ClusterConditionSecurityWarning.IsTrue(gotGKEConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionSecurityWarning.GetReason(gotGKEConfig)).To(Equal(reasonShieldedNodesDisabled))
+
+		gotGKEConfig, err = handler.updateUpstreamClusterState(ctx, gkeServiceMock, gotGKEConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.Phase).To(Equal(gkeConfigActivePhase))
+		Expect(gkev1.ClusterConditionSecurityWarning.IsTrue(gotGKEConfig)).To(BeTrue())
+	})
+
+	It("should report but not disable shielded nodes that the old create request enabled", func() {
+		gkeConfig.Spec.ShieldedNodes = &gkev1.GKEShieldedNodes{}
+		Expect(cl.Update(ctx, gkeConfig)).To(Succeed())
+		upstreamSpec.NodePools = upstreamSpec.NodePools[:1]
+		upstreamSpec.ShieldedNodes = &gkev1.GKEShieldedNodes{Enabled: true}
+
+		gotGKEConfig, err := handler.updateUpstreamClusterState(ctx, gkeServiceMock, gkeConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gkev1.ClusterConditionSecurityWarning.IsTrue(gotGKEConfig)).To(BeTrue())
+		Expect(gkev1.ClusterConditionSecurityWarning.GetReason(gotGKEConfig)).To(Equal(reasonShieldedNodesDrift))
+		Expect(gotGKEConfig.Status.AppliedShieldedNodes).To(BeNil())
+
+		// no update is sent to GKE
+		gotGKEConfig, err = handler.updateUpstreamClusterState(ctx, gkeServiceMock, gotGKEConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.Phase).To(Equal(gkeConfigActivePhase))
+	})
+
+	It("should remove orphan node pools but not node pools created by node auto-provisioning", func() {
+		gkeServiceMock.EXPECT().
+			NodePoolDelete(ctx, gke.NodePoolRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region, gkeConfig.Spec.ClusterName, orphanName)).
+			Return(&gkeapi.Operation{}, nil)
+
+		gotGKEConfig, err := handler.updateUpstreamClusterState(ctx, gkeServiceMock, gkeConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gkev1.ClusterConditionUpdating.GetReason(gotGKEConfig)).To(Equal(stepNodePools))
+
+		upstreamSpec.NodePools = upstreamSpec.NodePools[:1]
+		gotGKEConfig, err = handler.updateUpstreamClusterState(ctx, gkeServiceMock, gotGKEConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.Phase).To(Equal(gkeConfigActivePhase))
+	})
+})
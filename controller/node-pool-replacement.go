@@ -0,0 +1,321 @@
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+	gkeapi "google.golang.org/api/container/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/gke"
+)
+
+// Node pool replacement phases
+const (
+	nodePoolReplacementCreating = "Creating"
+	nodePoolReplacementDraining = "Draining"
+	nodePoolReplacementDeleting = "Deleting"
+)
+
+const (
+	// nodePoolLabel is the label GKE sets on nodes with the name of their node pool
+	nodePoolLabel = "cloud.google.com/gke-nodepool"
+	// mirrorPodAnnotation is set on static pods, which cannot be evicted
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+	// maxNodePoolNameLength is the maximum length of a GKE node pool name
+	maxNodePoolNameLength = 40
+)
+
+// upstreamNodePoolName returns the name of the upstream node pool backing the node pool with the given
+// name in the spec. It differs from the name in the spec once the node pool was replaced.
+func upstreamNodePoolName(config *gkev1.GKEClusterConfig, npName string) string {
+	if replacement, ok := config.Status.NodePoolReplacements[npName]; ok && replacement.UpstreamName != "" {
+		return replacement.UpstreamName
+	}
+	return npName
+}
+
+// ownedNodePoolNames returns the names of the upstream node pools backing the node pools of the spec,
+// including the node pools being created to replace them.
+func ownedNodePoolNames(config *gkev1.GKEClusterConfig, nodePools map[string]*gkev1.GKENodePoolConfig) map[string]bool {
+	owned := make(map[string]bool, len(nodePools))
+	for npName := range nodePools {
+		owned[upstreamNodePoolName(config, npName)] = true
+		if replacement := config.Status.NodePoolReplacements[npName]; replacement.Phase != "" {
+			owned[replacement.ReplacementName] = true
+		}
+	}
+	return owned
+}
+
+// nodePoolReplacementName returns the name of the node pool replacing the given upstream node pool,
+// the name in the spec suffixed with the number of the replacement, e.g. pool-r2.
+func nodePoolReplacementName(npName, upstreamName string) string {
+	count := 1
+	if suffix, ok := strings.CutPrefix(upstreamName, npName+"-r"); ok {
+		if n, err := strconv.Atoi(suffix); err == nil {
+			count = n + 1
+		}
+	}
+	suffix := fmt.Sprintf("-r%d", count)
+	if len(npName)+len(suffix) > maxNodePoolNameLength {
+		npName = strings.TrimRight(npName[:maxNodePoolNameLength-len(suffix)], "-")
+	}
+	return npName + suffix
+}
+
+// withUpstreamName returns the node pool with the name of the upstream node pool backing it, so that
+// updates are sent to the upstream node pool.
+func withUpstreamName(np *gkev1.GKENodePoolConfig, upstreamName string) *gkev1.GKENodePoolConfig {
+	if *np.Name == upstreamName {
+		return np
+	}
+	upstreamNodePool := *np
+	upstreamNodePool.Name = &upstreamName
+	return &upstreamNodePool
+}
+
+// startNodePoolReplacement records in the status that the upstream node pool backing the given node pool
+// is being replaced. The replacement is then carried out by replaceNodePool.
+func (h *Handler) startNodePoolReplacement(config *gkev1.GKEClusterConfig, npName string, reason error) (*gkev1.GKEClusterConfig, error) {
+	upstreamName := upstreamNodePoolName(config, npName)
+	replacement := gkev1.GKENodePoolReplacement{
+		UpstreamName:    upstreamName,
+		ReplacementName: nodePoolReplacementName(npName, upstreamName),
+		Phase:           nodePoolReplacementCreating,
+	}
+	logrus.Infof("Replacing node pool [%s] with node pool [%s] on cluster [%s (id: %s)]: %v", upstreamName, replacement.ReplacementName, config.Spec.ClusterName, config.Name, reason)
+	return h.setNodePoolReplacement(config, npName, replacement, nil)
+}
+
+// replaceNodePool carries out the replacement of an upstream node pool recorded in the status. The
+// replacement node pool is created with the configuration of the spec and once it is running, the nodes
+// of the replaced node pool are cordoned and drained before it is deleted. Each phase is recorded in the
+// status, so the replacement resumes where it left off if the controller is restarted.
+func (h *Handler) replaceNodePool(ctx context.Context, gkeClient *gke.OperationRecorder, config *gkev1.GKEClusterConfig, np *gkev1.GKENodePoolConfig) (*gkev1.GKEClusterConfig, error) {
+	npName := *np.Name
+	replacement := config.Status.NodePoolReplacements[npName]
+
+	switch replacement.Phase {
+	case nodePoolReplacementCreating:
+		nodePool, err := gke.GetNodePool(ctx, gkeClient, &config.Spec, replacement.ReplacementName)
+		if err != nil {
+			return config, err
+		}
+		if nodePool == nil {
+			logrus.Infof("Creating node pool [%s] to replace node pool [%s] on cluster [%s (id: %s)]", replacement.ReplacementName, replacement.UpstreamName, config.Spec.ClusterName, config.Name)
+			changed, err := gke.CreateNodePool(ctx, gkeClient, config, withUpstreamName(np, replacement.ReplacementName))
+			if err != nil {
+				return config, err
+			}
+			if changed == gke.Retry {
+				return h.enqueueUpdate(config, stepNodePoolReplacement, nil)
+			}
+			return h.enqueueUpdate(config, stepNodePoolReplacement, gkeClient.Operation())
+		}
+		switch nodePool.Status {
+		case NodePoolStatusRunning:
+			replacement.Phase = nodePoolReplacementDraining
+			return h.setNodePoolReplacement(config, npName, replacement, nil)
+		case NodePoolStatusError, NodePoolStatusRunningWithError:
+			return config, fmt.Errorf("node pool [%s] replacing node pool [%s] on cluster [%s (id: %s)] is in status %s: %s",
+				replacement.ReplacementName, replacement.UpstreamName, config.Spec.ClusterName, config.Name, nodePool.Status, nodePool.StatusMessage)
+		}
+		logrus.Infof("Waiting for node pool [%s] replacing node pool [%s] on cluster [%s (id: %s)] to be running", replacement.ReplacementName, replacement.UpstreamName, config.Spec.ClusterName, config.Name)
+		h.gkeEnqueueAfter(config.Namespace, config.Name, wait*time.Second)
+		return config, nil
+
+	case nodePoolReplacementDraining:
+		client, err := h.downstreamClient(ctx, config)
+		if err != nil {
+			return config, err
+		}
+		drained, err := drainNodePool(ctx, client, replacement.UpstreamName)
+		if err != nil {
+			return config, fmt.Errorf("error draining node pool [%s] on cluster [%s (id: %s)]: %w", replacement.UpstreamName, config.Spec.ClusterName, config.Name, err)
+		}
+		if !drained {
+			logrus.Infof("Waiting for node pool [%s] on cluster [%s (id: %s)] to be drained", replacement.UpstreamName, config.Spec.ClusterName, config.Name)
+			h.gkeEnqueueAfter(config.Namespace, config.Name, wait*time.Second)
+			return config, nil
+		}
+		replacement.Phase = nodePoolReplacementDeleting
+		return h.setNodePoolReplacement(config, npName, replacement, nil)
+
+	case nodePoolReplacementDeleting:
+		logrus.Infof("Removing node pool [%s] replaced by node pool [%s] from cluster [%s (id: %s)]", replacement.UpstreamName, replacement.ReplacementName, config.Spec.ClusterName, config.Name)
+		changed, err := gke.RemoveNodePool(ctx, gkeClient, config, replacement.UpstreamName)
+		if err != nil {
+			return config, err
+		}
+		if changed == gke.Retry {
+			return h.enqueueUpdate(config, stepNodePoolReplacement, nil)
+		}
+		return h.setNodePoolReplacement(config, npName, gkev1.GKENodePoolReplacement{
+			UpstreamName: replacement.ReplacementName,
+		}, gkeClient.Operation())
+	}
+	return config, fmt.Errorf("unknown phase [%s] replacing node pool [%s] on cluster [%s (id: %s)]", replacement.Phase, replacement.UpstreamName, config.Spec.ClusterName, config.Name)
+}
+
+// pruneNodePoolReplacements returns a copy of the config without the replacements of node pools that
+// are no longer in the spec, or nil if there are none. The upstream node pools of those replacements are
+// then removed like any other node pool that left the spec.
+func pruneNodePoolReplacements(config *gkev1.GKEClusterConfig, nodePools map[string]*gkev1.GKENodePoolConfig) *gkev1.GKEClusterConfig {
+	var pruned *gkev1.GKEClusterConfig
+	for npName := range config.Status.NodePoolReplacements {
+		if _, ok := nodePools[npName]; ok {
+			continue
+		}
+		if pruned == nil {
+			pruned = config.DeepCopy()
+		}
+		delete(pruned.Status.NodePoolReplacements, npName)
+	}
+	return pruned
+}
+
+// setNodePoolReplacement records the replacement of the given node pool in the status, along with the
+// operation started by the replacement, if any.
+func (h *Handler) setNodePoolReplacement(config *gkev1.GKEClusterConfig, npName string, replacement gkev1.GKENodePoolReplacement, operation *gkeapi.Operation) (*gkev1.GKEClusterConfig, error) {
+	config = config.DeepCopy()
+	if config.Status.NodePoolReplacements == nil {
+		config.Status.NodePoolReplacements = map[string]gkev1.GKENodePoolReplacement{}
+	}
+	config.Status.NodePoolReplacements[npName] = replacement
+	setUpdatingStatus(config, stepNodePoolReplacement, operation)
+	if operation == nil && replacement.Phase != "" {
+		setUpdatingConditions(config, stepNodePoolReplacement, fmt.Sprintf("%s node pool [%s] replacing node pool [%s]",
+			strings.ToLower(replacement.Phase), replacement.ReplacementName, replacement.UpstreamName))
+	}
+	return h.gkeCC.UpdateStatus(config)
+}
+
+// drainNodePool cordons the nodes of the given node pool and evicts their pods. It returns true once no
+// pods other than daemon set and static pods are left on the nodes. Evictions blocked by a pod
+// disruption budget are retried on the next call. Like kubectl drain without --force, it fails without
+// evicting anything if a pod is not managed by a controller, since such a pod would be lost.
+func drainNodePool(ctx context.Context, client kubernetes.Interface, nodePoolName string) (bool, error) {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+		LabelSelector: nodePoolLabel + "=" + nodePoolName,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var evictions []*corev1.Pod
+	var unmanaged []string
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !node.Spec.Unschedulable {
+			logrus.Infof("Cordoning node [%s] of node pool [%s]", node.Name, nodePoolName)
+			node = node.DeepCopy()
+			node.Spec.Unschedulable = true
+			if _, err := client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{}); err != nil {
+				return false, err
+			}
+		}
+
+		pods, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+			FieldSelector: "spec.nodeName=" + node.Name,
+		})
+		if err != nil {
+			return false, err
+		}
+		for j := range pods.Items {
+			pod := &pods.Items[j]
+			if !needsEviction(pod) {
+				continue
+			}
+			if metav1.GetControllerOf(pod) == nil {
+				unmanaged = append(unmanaged, pod.Namespace+"/"+pod.Name)
+			}
+			evictions = append(evictions, pod)
+		}
+	}
+	if len(unmanaged) > 0 {
+		return false, fmt.Errorf("pods [%s] are not managed by a controller and would be lost, delete them to continue", strings.Join(unmanaged, ", "))
+	}
+
+	for _, pod := range evictions {
+		if pod.DeletionTimestamp != nil {
+			// already being evicted
+			continue
+		}
+		err := client.PolicyV1().Evictions(pod.Namespace).Evict(ctx, &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			},
+		})
+		if errors.IsTooManyRequests(err) {
+			logrus.Debugf("eviction of pod [%s/%s] is blocked by a pod disruption budget, will retry", pod.Namespace, pod.Name)
+			continue
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			return false, err
+		}
+	}
+	return len(evictions) == 0, nil
+}
+
+// needsEviction returns false for pods that are not evicted when a node is drained: completed pods,
+// static pods and pods managed by a daemon set.
+func needsEviction(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return false
+	}
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "DaemonSet" {
+			return false
+		}
+	}
+	return true
+}
+
+// newDownstreamClient returns a client for the Kubernetes API of the GKE cluster, authenticated with the
+// credential secret of the cluster. The endpoint and CA are read from the CA secret created for the cluster.
+func (h *Handler) newDownstreamClient(ctx context.Context, config *gkev1.GKEClusterConfig) (kubernetes.Interface, error) {
+	caSecret, err := h.secretsCache.Get(config.Namespace, config.Name)
+	if err != nil {
+		return nil, fmt.Errorf("error getting CA secret for cluster [%s (id: %s)]: %w", config.Spec.ClusterName, config.Name, err)
+	}
+	ca, err := base64.StdEncoding.DecodeString(string(caSecret.Data["ca"]))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding CA for cluster [%s (id: %s)]: %w", config.Spec.ClusterName, config.Name, err)
+	}
+	ts, err := GetTokenSource(ctx, h.secrets, &config.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := kubernetes.NewForConfig(&rest.Config{
+		Host: "https://" + string(caSecret.Data["endpoint"]),
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: ca,
+		},
+		WrapTransport: func(rt http.RoundTripper) http.RoundTripper {
+			return &oauth2.Transport{Source: ts, Base: rt}
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
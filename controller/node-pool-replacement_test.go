@@ -0,0 +1,387 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	gkeapi "google.golang.org/api/container/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/gke"
+	"github.com/rancher/gke-operator/pkg/gke/services/mock_services"
+	"github.com/rancher/gke-operator/pkg/test"
+)
+
+var _ = Describe("nodePoolReplacementName", func() {
+	It("should number the replacements of a node pool", func() {
+		Expect(nodePoolReplacementName("pool", "pool")).To(Equal("pool-r1"))
+		Expect(nodePoolReplacementName("pool", "pool-r1")).To(Equal("pool-r2"))
+		Expect(nodePoolReplacementName("pool", "pool-r9")).To(Equal("pool-r10"))
+		Expect(nodePoolReplacementName("pool", "pool-rx")).To(Equal("pool-r1"))
+	})
+
+	It("should truncate the name to the maximum node pool name length", func() {
+		npName := strings.Repeat("a", 37) + "-bc"
+		name := nodePoolReplacementName(npName, npName)
+		Expect(name).To(HaveLen(maxNodePoolNameLength))
+		Expect(name).To(Equal(strings.Repeat("a", 37) + "-r1"))
+	})
+})
+
+var _ = Describe("ownedNodePoolNames", func() {
+	It("should include the upstream and replacement node pools", func() {
+		config := &gkev1.GKEClusterConfig{
+			Status: gkev1.GKEClusterConfigStatus{
+				NodePoolReplacements: map[string]gkev1.GKENodePoolReplacement{
+					"pool-a": {UpstreamName: "pool-a-r1"},
+					"pool-b": {UpstreamName: "pool-b", ReplacementName: "pool-b-r1", Phase: nodePoolReplacementDraining},
+				},
+			},
+		}
+		Expect(upstreamNodePoolName(config, "pool-a")).To(Equal("pool-a-r1"))
+		Expect(upstreamNodePoolName(config, "pool-c")).To(Equal("pool-c"))
+		Expect(ownedNodePoolNames(config, map[string]*gkev1.GKENodePoolConfig{
+			"pool-a": {},
+			"pool-b": {},
+			"pool-c": {},
+		})).To(Equal(map[string]bool{
+			"pool-a-r1": true,
+			"pool-b":    true,
+			"pool-b-r1": true,
+			"pool-c":    true,
+		}))
+	})
+})
+
+var _ = Describe("pruneNodePoolReplacements", func() {
+	It("should remove the replacements of node pools no longer in the spec", func() {
+		config := &gkev1.GKEClusterConfig{
+			Status: gkev1.GKEClusterConfigStatus{
+				NodePoolReplacements: map[string]gkev1.GKENodePoolReplacement{
+					"pool-a": {UpstreamName: "pool-a-r1"},
+					"pool-b": {UpstreamName: "pool-b", ReplacementName: "pool-b-r1", Phase: nodePoolReplacementDraining},
+				},
+			},
+		}
+		nodePools := map[string]*gkev1.GKENodePoolConfig{
+			"pool-a": {},
+			"pool-b": {},
+		}
+		Expect(pruneNodePoolReplacements(config, nodePools)).To(BeNil())
+
+		delete(nodePools, "pool-b")
+		pruned := pruneNodePoolReplacements(config, nodePools)
+		Expect(pruned.Status.NodePoolReplacements).To(Equal(map[string]gkev1.GKENodePoolReplacement{
+			"pool-a": {UpstreamName: "pool-a-r1"},
+		}))
+		Expect(config.Status.NodePoolReplacements).To(HaveLen(2))
+		Expect(ownedNodePoolNames(pruned, nodePools)).To(Equal(map[string]bool{"pool-a-r1": true}))
+	})
+})
+
+var _ = Describe("drainNodePool", func() {
+	var (
+		client       *fake.Clientset
+		evicted      []string
+		blocked      bool
+		nodeName     = "gke-test-cluster-pool-1"
+		isController = true
+	)
+
+	BeforeEach(func() {
+		evicted = nil
+		blocked = false
+		client = fake.NewSimpleClientset(
+			&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   nodeName,
+					Labels: map[string]string{nodePoolLabel: "pool"},
+				},
+			},
+			&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "gke-test-cluster-pool-r1-1",
+					Labels: map[string]string{nodePoolLabel: "pool-r1"},
+				},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "app",
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{
+						{Kind: "ReplicaSet", Name: "app", Controller: &isController},
+					},
+				},
+				Spec: corev1.PodSpec{NodeName: nodeName},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "agent",
+					Namespace: "kube-system",
+					OwnerReferences: []metav1.OwnerReference{
+						{Kind: "DaemonSet", Name: "agent"},
+					},
+				},
+				Spec: corev1.PodSpec{NodeName: nodeName},
+			},
+		)
+		client.PrependReactor("post", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "eviction" {
+				return false, nil, nil
+			}
+			if blocked {
+				return true, nil, apierrors.NewTooManyRequests("cannot evict pod as it would violate the pod's disruption budget", 10)
+			}
+			name := action.(clienttesting.GetAction).GetName()
+			evicted = append(evicted, name)
+			return true, nil, client.Tracker().Delete(corev1.SchemeGroupVersion.WithResource("pods"), action.GetNamespace(), name)
+		})
+	})
+
+	It("should cordon the nodes and evict their pods", func() {
+		drained, err := drainNodePool(ctx, client, "pool")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drained).To(BeFalse())
+		Expect(evicted).To(Equal([]string{"app"}))
+
+		node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(node.Spec.Unschedulable).To(BeTrue())
+		node, err = client.CoreV1().Nodes().Get(ctx, "gke-test-cluster-pool-r1-1", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(node.Spec.Unschedulable).To(BeFalse())
+
+		drained, err = drainNodePool(ctx, client, "pool")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drained).To(BeTrue())
+	})
+
+	It("should retry evictions blocked by a pod disruption budget", func() {
+		blocked = true
+		drained, err := drainNodePool(ctx, client, "pool")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(drained).To(BeFalse())
+
+		_, err = client.CoreV1().Pods("default").Get(ctx, "app", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not evict any pod while a pod is not managed by a controller", func() {
+		_, err := client.CoreV1().Pods("default").Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "bare",
+				Namespace: "default",
+			},
+			Spec: corev1.PodSpec{NodeName: nodeName},
+		}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		drained, err := drainNodePool(ctx, client, "pool")
+		Expect(err).To(MatchError("pods [default/bare] are not managed by a controller and would be lost, delete them to continue"))
+		Expect(drained).To(BeFalse())
+		Expect(evicted).To(BeEmpty())
+
+		_, err = client.CoreV1().Pods("default").Get(ctx, "bare", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not evict completed, static and daemon set pods", func() {
+		Expect(needsEviction(&corev1.Pod{})).To(BeTrue())
+		Expect(needsEviction(&corev1.Pod{
+			Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+		})).To(BeFalse())
+		Expect(needsEviction(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{mirrorPodAnnotation: "abc"},
+			},
+		})).To(BeFalse())
+		Expect(needsEviction(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				OwnerReferences: []metav1.OwnerReference{{Kind: "DaemonSet"}},
+			},
+		})).To(BeFalse())
+	})
+})
+
+var _ = Describe("replaceNodePool", func() {
+	var (
+		handler        *Handler
+		mockController *gomock.Controller
+		gkeServiceMock *mock_services.MockGKEClusterService
+		gkeConfig      *gkev1.GKEClusterConfig
+		nodePool       *gkev1.GKENodePoolConfig
+		enqueuedAfter  int
+		npName         = "pool"
+		version        = "1.30.5-gke.1014001"
+		nodeCount      = int64(1)
+		maxPods        = int64(110)
+	)
+
+	BeforeEach(func() {
+		enqueuedAfter = 0
+		mockController = gomock.NewController(GinkgoT())
+		gkeServiceMock = mock_services.NewMockGKEClusterService(mockController)
+
+		nodePool = &gkev1.GKENodePoolConfig{
+			Name:              &npName,
+			Version:           &version,
+			InitialNodeCount:  &nodeCount,
+			MaxPodsConstraint: &maxPods,
+			Autoscaling:       &gkev1.GKENodePoolAutoscaling{},
+			Management:        &gkev1.GKENodePoolManagement{},
+			Config: &gkev1.GKENodeConfig{
+				LocalSsdCount: 1,
+			},
+			ReplacementPolicy: gke.NodePoolReplacementPolicyReplace,
+		}
+		gkeConfig = &gkev1.GKEClusterConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-node-pool-replacement",
+				Namespace: "default",
+			},
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName: "test-cluster",
+				ProjectID:   "example-project-name",
+				Region:      "us-east1",
+				NodePools:   []gkev1.GKENodePoolConfig{*nodePool},
+			},
+		}
+		Expect(cl.Create(ctx, gkeConfig)).To(Succeed())
+
+		handler = &Handler{
+			gkeCC:        gkeFactory.Gke().V1().GKEClusterConfig(),
+			secrets:      coreFactory.Core().V1().Secret(),
+			secretsCache: coreFactory.Core().V1().Secret().Cache(),
+			gkeEnqueue:   func(_, _ string) {},
+			gkeEnqueueAfter: func(_, _ string, _ time.Duration) {
+				enqueuedAfter++
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(test.CleanupAndWait(ctx, cl, gkeConfig)).To(Succeed())
+		mockController.Finish()
+	})
+
+	withReplacement := func(phase string) {
+		gkeConfig.Status.NodePoolReplacements = map[string]gkev1.GKENodePoolReplacement{
+			npName: {
+				UpstreamName:    npName,
+				ReplacementName: "pool-r1",
+				Phase:           phase,
+			},
+		}
+		var err error
+		gkeConfig, err = handler.gkeCC.UpdateStatus(gkeConfig)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	It("should record the start of the replacement", func() {
+		gotGKEConfig, err := handler.startNodePoolReplacement(gkeConfig, npName, errors.New("field [localSsdCount] cannot be updated in place"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.NodePoolReplacements).To(Equal(map[string]gkev1.GKENodePoolReplacement{
+			npName: {
+				UpstreamName:    npName,
+				ReplacementName: "pool-r1",
+				Phase:           nodePoolReplacementCreating,
+			},
+		}))
+		Expect(gkev1.ClusterConditionUpdating.GetReason(gotGKEConfig)).To(Equal(stepNodePoolReplacement))
+		Expect(gkev1.ClusterConditionUpdating.GetMessage(gotGKEConfig)).To(Equal("creating node pool [pool-r1] replacing node pool [pool]"))
+	})
+
+	It("should create the replacement node pool", func() {
+		withReplacement(nodePoolReplacementCreating)
+		gkeServiceMock.EXPECT().
+			NodePoolGet(ctx, gke.NodePoolRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region, gkeConfig.Spec.ClusterName, "pool-r1")).
+			Return(nil, errors.New("googleapi: Error 404: Not found: pool-r1., notFound"))
+		gkeServiceMock.EXPECT().
+			NodePoolCreate(ctx, gke.ClusterRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region, gkeConfig.Spec.ClusterName), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, request *gkeapi.CreateNodePoolRequest) (*gkeapi.Operation, error) {
+				Expect(request.NodePool.Name).To(Equal("pool-r1"))
+				return &gkeapi.Operation{
+					Name:          "operation-1",
+					OperationType: "CREATE_NODE_POOL",
+					Status:        "RUNNING",
+				}, nil
+			})
+
+		gotGKEConfig, err := handler.replaceNodePool(ctx, gke.NewOperationRecorder(gkeServiceMock), gkeConfig, nodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.PendingOperation).NotTo(BeNil())
+		Expect(gotGKEConfig.Status.PendingOperation.Name).To(Equal("operation-1"))
+		Expect(gotGKEConfig.Status.NodePoolReplacements[npName].Phase).To(Equal(nodePoolReplacementCreating))
+	})
+
+	It("should wait for the replacement node pool to be running", func() {
+		withReplacement(nodePoolReplacementCreating)
+		gkeServiceMock.EXPECT().
+			NodePoolGet(ctx, gke.NodePoolRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region, gkeConfig.Spec.ClusterName, "pool-r1")).
+			Return(&gkeapi.NodePool{Name: "pool-r1", Status: "PROVISIONING"}, nil)
+
+		gotGKEConfig, err := handler.replaceNodePool(ctx, gke.NewOperationRecorder(gkeServiceMock), gkeConfig, nodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.NodePoolReplacements[npName].Phase).To(Equal(nodePoolReplacementCreating))
+		Expect(enqueuedAfter).To(Equal(1))
+	})
+
+	It("should drain the replaced node pool once the replacement is running", func() {
+		withReplacement(nodePoolReplacementCreating)
+		gkeServiceMock.EXPECT().
+			NodePoolGet(ctx, gke.NodePoolRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region, gkeConfig.Spec.ClusterName, "pool-r1")).
+			Return(&gkeapi.NodePool{Name: "pool-r1", Status: NodePoolStatusRunning}, nil)
+
+		gotGKEConfig, err := handler.replaceNodePool(ctx, gke.NewOperationRecorder(gkeServiceMock), gkeConfig, nodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.NodePoolReplacements[npName].Phase).To(Equal(nodePoolReplacementDraining))
+
+		handler.downstreamClient = func(_ context.Context, _ *gkev1.GKEClusterConfig) (kubernetes.Interface, error) {
+			return fake.NewSimpleClientset(), nil
+		}
+		gotGKEConfig, err = handler.replaceNodePool(ctx, gke.NewOperationRecorder(gkeServiceMock), gotGKEConfig, nodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.NodePoolReplacements[npName].Phase).To(Equal(nodePoolReplacementDeleting))
+	})
+
+	It("should return error if the replacement node pool fails", func() {
+		withReplacement(nodePoolReplacementCreating)
+		gkeServiceMock.EXPECT().
+			NodePoolGet(ctx, gke.NodePoolRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region, gkeConfig.Spec.ClusterName, "pool-r1")).
+			Return(&gkeapi.NodePool{Name: "pool-r1", Status: NodePoolStatusError, StatusMessage: "quota exceeded"}, nil)
+
+		_, err := handler.replaceNodePool(ctx, gke.NewOperationRecorder(gkeServiceMock), gkeConfig, nodePool)
+		Expect(err).To(MatchError("node pool [pool-r1] replacing node pool [pool] on cluster [test-cluster (id: test-node-pool-replacement)] is in status ERROR: quota exceeded"))
+	})
+
+	It("should delete the replaced node pool", func() {
+		withReplacement(nodePoolReplacementDeleting)
+		gkeServiceMock.EXPECT().
+			NodePoolDelete(ctx, gke.NodePoolRRN(gkeConfig.Spec.ProjectID, gkeConfig.Spec.Region, gkeConfig.Spec.ClusterName, npName)).
+			Return(&gkeapi.Operation{
+				Name:          "operation-2",
+				OperationType: "DELETE_NODE_POOL",
+				Status:        "RUNNING",
+			}, nil)
+
+		gotGKEConfig, err := handler.replaceNodePool(ctx, gke.NewOperationRecorder(gkeServiceMock), gkeConfig, nodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gotGKEConfig.Status.NodePoolReplacements).To(Equal(map[string]gkev1.GKENodePoolReplacement{
+			npName: {UpstreamName: "pool-r1"},
+		}))
+		Expect(gotGKEConfig.Status.PendingOperation.Name).To(Equal("operation-2"))
+		Expect(upstreamNodePoolName(gotGKEConfig, npName)).To(Equal("pool-r1"))
+	})
+})
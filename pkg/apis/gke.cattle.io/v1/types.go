@@ -17,9 +17,39 @@ limitations under the License.
 package v1
 
 import (
+	"github.com/rancher/wrangler/v3/pkg/condition"
+	"github.com/rancher/wrangler/v3/pkg/genericcondition"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Cluster conditions
+const (
+	// ClusterConditionReady is true when the cluster is running and the latest
+	// generation of the spec has been reconciled.
+	ClusterConditionReady condition.Cond = "Ready"
+
+	// ClusterConditionProvisioning is true while the cluster is being created or imported.
+	ClusterConditionProvisioning condition.Cond = "Provisioning"
+
+	// ClusterConditionUpdating is true while changes are being applied to the
+	// cluster. The reason names the update step that is being waited on.
+	ClusterConditionUpdating condition.Cond = "Updating"
+
+	// ClusterConditionDegraded is true when the last reconcile returned an error.
+	ClusterConditionDegraded condition.Cond = "Degraded"
+
+	// ClusterConditionCredentialsValid is true when the Google credential secret
+	// could be read and used to build a GKE client.
+	ClusterConditionCredentialsValid condition.Cond = "CredentialsValid"
+
+	// ClusterConditionDeleting is true while the cluster is being deleted.
+	ClusterConditionDeleting condition.Cond = "Deleting"
+
+	// ClusterConditionSecurityWarning is true when the spec weakens the security of the
+	// cluster. The reason names the setting that caused it.
+	ClusterConditionSecurityWarning condition.Cond = "SecurityWarning"
+)
+
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:printcolumn:name="ProjectID",type="string",JSONPath=".spec.projectID"
@@ -27,6 +57,7 @@ import (
 // +kubebuilder:printcolumn:name="KubernetesVersion",type="string",JSONPath=".spec.kubernetesVersion"
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="FailureMessage",type="string",JSONPath=".status.failureMessage"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status"
 
 type GKEClusterConfig struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -90,6 +121,12 @@ type GKEClusterConfigSpec struct {
 	// +optional
 	KubernetesVersion *string `json:"kubernetesVersion" norman:"pointer"`
 
+	// ReleaseChannel enrolls the cluster in a release channel, one of RAPID, REGULAR, STABLE or
+	// EXTENDED. GKE upgrades clusters enrolled in a channel automatically. UNSPECIFIED unenrolls
+	// the cluster.
+	// +optional
+	ReleaseChannel *string `json:"releaseChannel,omitempty" norman:"pointer"`
+
 	// LoggingService is the logging service to use.
 	// +optional
 	LoggingService *string `json:"loggingService" norman:"pointer"`
@@ -115,6 +152,12 @@ type GKEClusterConfigSpec struct {
 	// +kubebuilder:default=false
 	NetworkPolicyEnabled *bool `json:"networkPolicyEnabled,omitempty"`
 
+	// DatapathProvider is the datapath provider of the cluster, LEGACY_DATAPATH or ADVANCED_DATAPATH
+	// (Dataplane V2). Dataplane V2 enforces network policies itself, so NetworkPolicyEnabled and the
+	// NetworkPolicyConfig addon must then be disabled. It can only be set at creation.
+	// +optional
+	DatapathProvider *string `json:"datapathProvider,omitempty" norman:"pointer"`
+
 	// PrivateClusterConfig contains private cluster configuration.
 	// +optional
 	PrivateClusterConfig *GKEPrivateClusterConfig `json:"privateClusterConfig,omitempty"`
@@ -135,6 +178,11 @@ type GKEClusterConfigSpec struct {
 	// +optional
 	MaintenanceWindow *string `json:"maintenanceWindow,omitempty" norman:"pointer"`
 
+	// MaintenancePolicy is the structured maintenance policy configuration, supporting daily or
+	// recurring windows and maintenance exclusions. If set, MaintenanceWindow must be empty.
+	// +optional
+	MaintenancePolicy *GKEMaintenancePolicy `json:"maintenancePolicy,omitempty"`
+
 	// GKE Autopilot is a mode of operation in GKE in which Google manages your cluster configuration,
 	// including your nodes, scaling, security, and other preconfigured settings.
 	// +optional
@@ -172,6 +220,126 @@ type GKEClusterConfigSpec struct {
 	// IntraNodeVisibilityConfig defines intra-node visibility configuration.
 	// +optional
 	IntraNodeVisibilityConfig *GKEIntraNodeVisibilityConfig `json:"intraNodeVisibilityConfig,omitempty"`
+
+	// ClusterAutoscaling defines the cluster-wide autoscaling configuration, including node
+	// auto-provisioning. Per node pool autoscaling is configured on the node pools.
+	// +optional
+	ClusterAutoscaling *GKEClusterAutoscaling `json:"clusterAutoscaling,omitempty"`
+
+	// DNSConfig configures the DNS provider used for cluster DNS, such as Cloud DNS.
+	// +optional
+	DNSConfig *GKEDNSConfig `json:"dnsConfig,omitempty"`
+
+	// ControlPlaneEndpointsConfig configures the DNS-based and IP-based endpoints of the control
+	// plane.
+	// +optional
+	ControlPlaneEndpointsConfig *GKEControlPlaneEndpointsConfig `json:"controlPlaneEndpointsConfig,omitempty"`
+}
+
+type GKEControlPlaneEndpointsConfig struct {
+	// DNSEndpointConfig configures the DNS-based endpoint of the control plane, which is
+	// authorized through IAM instead of authorized networks.
+	// +optional
+	DNSEndpointConfig *GKEDNSEndpointConfig `json:"dnsEndpointConfig,omitempty"`
+
+	// IPEndpointsConfig configures the IP-based endpoints of the control plane.
+	// +optional
+	IPEndpointsConfig *GKEIPEndpointsConfig `json:"ipEndpointsConfig,omitempty"`
+}
+
+type GKEDNSEndpointConfig struct {
+	// AllowExternalTraffic allows traffic from outside the VPC network to the DNS endpoint.
+	// +optional
+	AllowExternalTraffic bool `json:"allowExternalTraffic"`
+}
+
+type GKEIPEndpointsConfig struct {
+	// Enabled indicates whether the IP-based endpoints are enabled. It is left as configured
+	// by GKE when not set.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// GlobalAccess allows access to the private IP endpoint from all regions. It is the same
+	// setting as privateClusterConfig.masterGlobalAccessConfig.
+	// +optional
+	GlobalAccess *bool `json:"globalAccess,omitempty"`
+
+	// AuthorizedNetworksConfig restricts access to the IP-based endpoints. It is the same
+	// setting as masterAuthorizedNetworks.
+	// +optional
+	AuthorizedNetworksConfig *GKEMasterAuthorizedNetworksConfig `json:"authorizedNetworksConfig,omitempty"`
+}
+
+type GKEDNSConfig struct {
+	// ClusterDNS is the DNS provider, one of PLATFORM_DEFAULT, CLOUD_DNS or KUBE_DNS.
+	// +optional
+	ClusterDNS string `json:"clusterDns,omitempty"`
+
+	// ClusterDNSScope is the scope of the DNS records, CLUSTER_SCOPE or VPC_SCOPE. It requires
+	// Cloud DNS.
+	// +optional
+	ClusterDNSScope string `json:"clusterDnsScope,omitempty"`
+
+	// ClusterDNSDomain is the custom cluster DNS domain, required for VPC_SCOPE.
+	// +optional
+	ClusterDNSDomain string `json:"clusterDnsDomain,omitempty"`
+}
+
+type GKEClusterAutoscaling struct {
+	// EnableNodeAutoprovisioning indicates whether GKE creates and deletes node pools
+	// automatically based on the resource requests of pending pods.
+	// +optional
+	EnableNodeAutoprovisioning bool `json:"enableNodeAutoprovisioning,omitempty"`
+
+	// ResourceLimits are the limits of the resources, such as cpu, memory or a GPU type, of the
+	// cluster. The cpu and memory limits are required when node auto-provisioning is enabled.
+	// +optional
+	ResourceLimits []GKEResourceLimit `json:"resourceLimits,omitempty"`
+
+	// AutoscalingProfile is the profile of the cluster autoscaler, either BALANCED or
+	// OPTIMIZE_UTILIZATION.
+	// +optional
+	// +kubebuilder:validation:Enum=BALANCED;OPTIMIZE_UTILIZATION
+	AutoscalingProfile string `json:"autoscalingProfile,omitempty"`
+
+	// AutoprovisioningNodePoolDefaults are the defaults of the node pools created by node
+	// auto-provisioning.
+	// +optional
+	AutoprovisioningNodePoolDefaults *GKEAutoprovisioningNodePoolDefaults `json:"autoprovisioningNodePoolDefaults,omitempty"`
+}
+
+type GKEResourceLimit struct {
+	// ResourceType is the type of the resource, cpu, memory in GB or a GPU type such as nvidia-tesla-t4.
+	// +kubebuilder:validation:Required
+	ResourceType string `json:"resourceType,omitempty"`
+
+	// Minimum is the minimum amount of the resource in the cluster.
+	// +optional
+	Minimum int64 `json:"minimum,omitempty"`
+
+	// Maximum is the maximum amount of the resource in the cluster.
+	// +kubebuilder:validation:Required
+	Maximum int64 `json:"maximum,omitempty"`
+}
+
+type GKEAutoprovisioningNodePoolDefaults struct {
+	// ServiceAccount is the email address of the service account assigned to the nodes.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// OauthScopes are the OAuth scopes of the nodes.
+	// +optional
+	OauthScopes []string `json:"oauthScopes,omitempty"`
+
+	// BootDiskKmsKey is the Customer Managed Encryption Key used to encrypt the boot disks of
+	// the nodes, of the form
+	// projects/[PROJECT_ID]/locations/[LOCATION]/keyRings/[RING_NAME]/cryptoKeys/[KEY_NAME]
+	// +optional
+	BootDiskKmsKey string `json:"bootDiskKmsKey,omitempty"`
+
+	// ShieldedInstanceConfig defines the shielded instance configuration of the nodes.
+	// +optional
+	ShieldedInstanceConfig *GKEShieldedInstanceConfig `json:"shieldedInstanceConfig,omitempty"`
 }
 
 type GKEIPAllocationPolicy struct {
@@ -208,6 +376,27 @@ type GKEIPAllocationPolicy struct {
 	// +optional
 	// +kubebuilder:default=true
 	UseIPAliases bool `json:"useIpAliases,omitempty"`
+
+	// StackType is the IP stack type of the cluster, IPV4 or IPV4_IPV6 (dual-stack). Dual-stack
+	// clusters must be VPC-native and use Dataplane V2.
+	// +optional
+	StackType string `json:"stackType,omitempty"`
+
+	// IPv6AccessType is the access type of the IPv6 addresses of dual-stack clusters, INTERNAL or
+	// EXTERNAL. It can only be set at creation.
+	// +optional
+	IPv6AccessType string `json:"ipv6AccessType,omitempty"`
+
+	// AdditionalPodRangesConfig lists additional secondary ranges used for pods, next to
+	// ClusterSecondaryRangeName. The ranges are added once the cluster is created.
+	// +optional
+	AdditionalPodRangesConfig *GKEAdditionalPodRangesConfig `json:"additionalPodRangesConfig,omitempty"`
+}
+
+type GKEAdditionalPodRangesConfig struct {
+	// PodRangeNames are the names of the additional secondary ranges of the subnetwork.
+	// +optional
+	PodRangeNames []string `json:"podRangeNames"`
 }
 
 type GKEPrivateClusterConfig struct {
@@ -224,6 +413,22 @@ type GKEPrivateClusterConfig struct {
 	// MasterIpv4CidrBlock is the IPv4 CIDR block for the master.
 	// +kubebuilder:validation:Required
 	MasterIpv4CidrBlock string `json:"masterIpv4CidrBlock,omitempty"`
+
+	// MasterGlobalAccessConfig controls whether the private endpoint of the master is reachable
+	// from all regions of the network.
+	// +optional
+	MasterGlobalAccessConfig *GKEMasterGlobalAccessConfig `json:"masterGlobalAccessConfig,omitempty"`
+
+	// PrivateEndpointSubnetwork is the subnetwork where the private endpoint of the master is
+	// provisioned, instead of MasterIpv4CidrBlock.
+	// +optional
+	PrivateEndpointSubnetwork string `json:"privateEndpointSubnetwork,omitempty"`
+}
+
+type GKEMasterGlobalAccessConfig struct {
+	// Enabled allows access to the private endpoint of the master from all regions.
+	// +optional
+	Enabled bool `json:"enabled"`
 }
 
 type GKEClusterConfigStatus struct {
@@ -234,6 +439,72 @@ type GKEClusterConfigStatus struct {
 	// FailureMessage contains an optional failure message for the cluster.
 	// +optional
 	FailureMessage string `json:"failureMessage,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the spec observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions contains the latest observations of the cluster state.
+	// +optional
+	Conditions []genericcondition.GenericCondition `json:"conditions,omitempty"`
+
+	// PendingOperation is the GKE operation the controller is waiting on before
+	// sending further updates.
+	// +optional
+	PendingOperation *GKEOperation `json:"pendingOperation,omitempty"`
+
+	// NodePoolReplacements tracks the node pools replaced because their configuration could not
+	// be updated in place, keyed by the name of the node pool in the spec.
+	// +optional
+	NodePoolReplacements map[string]GKENodePoolReplacement `json:"nodePoolReplacements,omitempty"`
+
+	// AppliedShieldedNodes is the shielded nodes configuration of the spec that was last applied
+	// to the cluster or found in sync with it. A difference between the spec and the cluster is
+	// only reconciled once the spec changes from this configuration, otherwise it is reported.
+	// +optional
+	AppliedShieldedNodes *GKEShieldedNodes `json:"appliedShieldedNodes,omitempty"`
+}
+
+// GKENodePoolReplacement tracks the upstream node pool backing a node pool of the spec, and the
+// progress of its replacement by a node pool with the updated configuration.
+type GKENodePoolReplacement struct {
+	// UpstreamName is the name of the upstream node pool currently backing the node pool.
+	UpstreamName string `json:"upstreamName,omitempty"`
+
+	// ReplacementName is the name of the node pool replacing the upstream node pool.
+	// +optional
+	ReplacementName string `json:"replacementName,omitempty"`
+
+	// Phase is the phase of the replacement, either Creating, Draining or Deleting.
+	// It is empty once the replacement is complete.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+}
+
+// GKEOperation describes a GKE long-running operation started by the controller.
+type GKEOperation struct {
+	// Name is the server-assigned ID of the operation.
+	Name string `json:"name,omitempty"`
+
+	// OperationType is the type of the operation, e.g. UPDATE_CLUSTER.
+	// +optional
+	OperationType string `json:"operationType,omitempty"`
+
+	// TargetLink is the URL of the cluster or node pool the operation is performed on.
+	// +optional
+	TargetLink string `json:"targetLink,omitempty"`
+
+	// Status is the current status of the operation.
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// Progress is a summary of the progress reported by GKE for the operation.
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// StartTime is the time the operation started, in RFC3339 format.
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
 }
 
 type GKEClusterAddons struct {
@@ -251,6 +522,36 @@ type GKEClusterAddons struct {
 	// +optional
 	// +kubebuilder:default=false
 	NetworkPolicyConfig bool `json:"networkPolicyConfig,omitempty"`
+
+	// The following addons are left as configured by GKE when they are not set.
+
+	// GcePersistentDiskCsiDriver indicates whether the Compute Engine persistent disk CSI driver is enabled.
+	// +optional
+	GcePersistentDiskCsiDriver *bool `json:"gcePersistentDiskCsiDriver,omitempty"`
+
+	// GcpFilestoreCsiDriver indicates whether the Filestore CSI driver is enabled.
+	// +optional
+	GcpFilestoreCsiDriver *bool `json:"gcpFilestoreCsiDriver,omitempty"`
+
+	// GcsFuseCsiDriver indicates whether the Cloud Storage FUSE CSI driver is enabled.
+	// +optional
+	GcsFuseCsiDriver *bool `json:"gcsFuseCsiDriver,omitempty"`
+
+	// DNSCacheConfig indicates whether NodeLocal DNSCache is enabled.
+	// +optional
+	DNSCacheConfig *bool `json:"dnsCacheConfig,omitempty"`
+
+	// ConfigConnectorConfig indicates whether Config Connector is enabled. It requires workload identity.
+	// +optional
+	ConfigConnectorConfig *bool `json:"configConnectorConfig,omitempty"`
+
+	// GkeBackupAgentConfig indicates whether the Backup for GKE agent is enabled.
+	// +optional
+	GkeBackupAgentConfig *bool `json:"gkeBackupAgentConfig,omitempty"`
+
+	// VerticalPodAutoscaling indicates whether vertical pod autoscaling is enabled for the cluster.
+	// +optional
+	VerticalPodAutoscaling *bool `json:"verticalPodAutoscaling,omitempty"`
 }
 
 type GKENodePoolConfig struct {
@@ -266,10 +567,20 @@ type GKENodePoolConfig struct {
 	// +kubebuilder:validation:Required
 	InitialNodeCount *int64 `json:"initialNodeCount,omitempty"`
 
+	// Locations are the zones where the nodes of the node pool run. They must be a subset of the
+	// zones of the cluster. If not specified, the zones of the cluster are used.
+	// +optional
+	Locations []string `json:"locations,omitempty"`
+
 	// MaxPodsConstraint is the maximum number of pods that can run on a node in the node pool.
 	// +optional
 	MaxPodsConstraint *int64 `json:"maxPodsConstraint,omitempty"`
 
+	// NetworkConfig specifies the pod IP range of the node pool, and whether its nodes are private
+	// if it differs from the cluster. The pod range can only be set when the node pool is created.
+	// +optional
+	NetworkConfig *GKENodeNetworkConfig `json:"networkConfig,omitempty"`
+
 	// Name is the name of the node pool.
 	// +kubebuilder:validation:Required
 	Name *string `json:"name,omitempty" norman:"pointer"`
@@ -281,6 +592,87 @@ type GKENodePoolConfig struct {
 	// Management specifies the management configuration for the node pool.
 	// +optional
 	Management *GKENodePoolManagement `json:"management,omitempty"`
+
+	// UpgradeSettings specifies the strategy used when the nodes of the node pool are upgraded.
+	// +optional
+	UpgradeSettings *GKENodePoolUpgradeSettings `json:"upgradeSettings,omitempty"`
+
+	// ReplacementPolicy specifies how changes to fields that GKE cannot update in place are applied.
+	// When set to Replace, the node pool is replaced by a new node pool with the updated configuration
+	// and the nodes of the old node pool are drained before it is deleted. Otherwise, such changes
+	// are rejected.
+	// +optional
+	// +kubebuilder:validation:Enum=Replace
+	ReplacementPolicy string `json:"replacementPolicy,omitempty"`
+}
+
+type GKENodeNetworkConfig struct {
+	// PodRange is the name of the secondary range used for the pods of the node pool.
+	// +optional
+	PodRange string `json:"podRange,omitempty"`
+
+	// PodIpv4CidrBlock is the IP range used for the pods of the node pool, when a new range is
+	// created with CreatePodRange.
+	// +optional
+	PodIpv4CidrBlock string `json:"podIpv4CidrBlock,omitempty"`
+
+	// CreatePodRange indicates whether a new secondary range is created for the pods of the node
+	// pool, rather than using the existing PodRange.
+	// +optional
+	CreatePodRange bool `json:"createPodRange,omitempty"`
+
+	// EnablePrivateNodes overrides whether the nodes of the node pool have internal IP addresses
+	// only. If not set, the private cluster configuration is used.
+	// +optional
+	EnablePrivateNodes *bool `json:"enablePrivateNodes,omitempty"`
+}
+
+type GKENodePoolUpgradeSettings struct {
+	// Strategy is the node pool upgrade strategy, either SURGE or BLUE_GREEN.
+	// +optional
+	// +kubebuilder:validation:Enum=SURGE;BLUE_GREEN
+	Strategy string `json:"strategy,omitempty"`
+
+	// MaxSurge is the maximum number of nodes that can be created beyond the current size
+	// of the node pool during a surge upgrade.
+	// +optional
+	MaxSurge int64 `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the maximum number of nodes that can be simultaneously unavailable
+	// during a surge upgrade.
+	// +optional
+	MaxUnavailable int64 `json:"maxUnavailable,omitempty"`
+
+	// BlueGreenSettings specifies the settings for a blue-green upgrade.
+	// +optional
+	BlueGreenSettings *GKEBlueGreenSettings `json:"blueGreenSettings,omitempty"`
+}
+
+type GKEBlueGreenSettings struct {
+	// NodePoolSoakDuration is the time to wait after draining the blue pool before deleting it,
+	// as a duration in seconds such as "3600s".
+	// +optional
+	NodePoolSoakDuration string `json:"nodePoolSoakDuration,omitempty"`
+
+	// StandardRolloutPolicy specifies how the blue pool is drained in batches.
+	// +optional
+	StandardRolloutPolicy *GKEStandardRolloutPolicy `json:"standardRolloutPolicy,omitempty"`
+}
+
+type GKEStandardRolloutPolicy struct {
+	// BatchNodeCount is the number of blue nodes drained in a batch.
+	// Only one of batchNodeCount or batchPercentage can be set.
+	// +optional
+	BatchNodeCount int64 `json:"batchNodeCount,omitempty"`
+
+	// BatchPercentage is the percentage of blue nodes drained in a batch, between 0.0 and 1.0.
+	// +optional
+	BatchPercentage float64 `json:"batchPercentage,omitempty"`
+
+	// BatchSoakDuration is the time to wait after each batch is drained, as a duration in
+	// seconds such as "300s".
+	// +optional
+	BatchSoakDuration string `json:"batchSoakDuration,omitempty"`
 }
 
 type GKENodePoolAutoscaling struct {
@@ -296,9 +688,18 @@ type GKENodePoolAutoscaling struct {
 	// MinNodeCount is the minimum number of nodes in the node pool when autoscaling is enabled.
 	// +optional
 	MinNodeCount int64 `json:"minNodeCount,omitempty"`
+
+	// Autoprovisioned is set by GKE on node pools created by node auto-provisioning, which are
+	// managed by GKE. It is ignored in the spec.
+	// +optional
+	Autoprovisioned bool `json:"autoprovisioned,omitempty"`
 }
 
 type GKENodeConfig struct {
+	// Accelerators are the hardware accelerators, such as GPUs, attached to each node.
+	// +optional
+	Accelerators []GKEAcceleratorConfig `json:"accelerators,omitempty"`
+
 	// BootDiskKmsKey is the Customer Managed Encryption Key used to encrypt
 	// the boot disk attached to each node in the node pool. This should be
 	// of the form
@@ -339,6 +740,15 @@ type GKENodeConfig struct {
 	// +kubebuilder:default=false
 	Preemptible bool `json:"preemptible,omitempty"`
 
+	// ResourceLabels are the GCP resource labels applied to the Compute Engine instances of the node.
+	// +optional
+	ResourceLabels map[string]string `json:"resourceLabels,omitempty"`
+
+	// Spot indicates whether the nodes are Spot VMs. Spot VMs replace preemptible VMs and
+	// cannot be combined with them.
+	// +optional
+	Spot bool `json:"spot,omitempty"`
+
 	// Tags are the tags associated with the node.
 	// +optional
 	Tags []string `json:"tags,omitempty"`
@@ -361,10 +771,48 @@ type GKENodeConfig struct {
 	WorkloadMetadataConfig *GKEWorkloadMetadataConfig `json:"workloadMetadataConfig,omitempty"`
 }
 
+type GKEAcceleratorConfig struct {
+	// AcceleratorCount is the number of accelerators attached to each node.
+	// +kubebuilder:validation:Required
+	AcceleratorCount int64 `json:"acceleratorCount,omitempty"`
+
+	// AcceleratorType is the accelerator type, such as nvidia-tesla-t4.
+	// +kubebuilder:validation:Required
+	AcceleratorType string `json:"acceleratorType,omitempty"`
+
+	// GpuDriverInstallationConfig defines how the GPU drivers are installed on the nodes.
+	// +optional
+	GpuDriverInstallationConfig *GKEGPUDriverInstallationConfig `json:"gpuDriverInstallationConfig,omitempty"`
+
+	// GpuPartitionSize is the size of the partitions to create on the GPU, such as 1g.5gb.
+	// +optional
+	GpuPartitionSize string `json:"gpuPartitionSize,omitempty"`
+
+	// GpuSharingConfig defines how the GPUs are shared between containers.
+	// +optional
+	GpuSharingConfig *GKEGPUSharingConfig `json:"gpuSharingConfig,omitempty"`
+}
+
+type GKEGPUDriverInstallationConfig struct {
+	// GpuDriverVersion is the version of the GPU driver to install.
+	// +kubebuilder:validation:Enum=INSTALLATION_DISABLED;DEFAULT;LATEST
+	GpuDriverVersion string `json:"gpuDriverVersion,omitempty"`
+}
+
+type GKEGPUSharingConfig struct {
+	// GpuSharingStrategy is the strategy used to share a GPU between containers.
+	// +kubebuilder:validation:Enum=TIME_SHARING;MPS
+	GpuSharingStrategy string `json:"gpuSharingStrategy,omitempty"`
+
+	// MaxSharedClientsPerGpu is the maximum number of containers that can share a GPU.
+	MaxSharedClientsPerGpu int64 `json:"maxSharedClientsPerGpu,omitempty"`
+}
+
 type GKENodeTaintConfig struct {
 	// Effect is the effect of the taint, which can be NoSchedule or PreferNoSchedule or NoExecute.
+	// The GKE style NO_SCHEDULE, PREFER_NO_SCHEDULE and NO_EXECUTE are accepted as well.
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute
+	// +kubebuilder:validation:Enum=NoSchedule;PreferNoSchedule;NoExecute;NO_SCHEDULE;PREFER_NO_SCHEDULE;NO_EXECUTE
 	Effect string `json:"effect,omitempty"`
 
 	// Key is the key of the taint.
@@ -426,6 +874,54 @@ type CMEKConfig struct {
 	RingName string `json:"ringName,omitempty"`
 }
 
+// GKEMaintenancePolicy defines when GKE may perform automatic maintenance on the cluster
+type GKEMaintenancePolicy struct {
+	// DailyMaintenanceWindow starts maintenance at the same time every day.
+	// Only one of DailyMaintenanceWindow or RecurringWindow can be set.
+	// +optional
+	DailyMaintenanceWindow *GKEDailyMaintenanceWindow `json:"dailyMaintenanceWindow,omitempty"`
+	// RecurringWindow repeats maintenance according to an RFC 5545 RRULE.
+	// +optional
+	RecurringWindow *GKERecurringTimeWindow `json:"recurringWindow,omitempty"`
+	// MaintenanceExclusions are named time windows during which automatic maintenance is restricted.
+	// +optional
+	MaintenanceExclusions []GKEMaintenanceExclusion `json:"maintenanceExclusions,omitempty"`
+	// ResourceVersion is the version of the upstream maintenance policy, used to prevent
+	// concurrent updates. It is read from GKE and ignored when set in the spec.
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// GKEDailyMaintenanceWindow defines a daily maintenance window
+type GKEDailyMaintenanceWindow struct {
+	// StartTime is the time the window starts in "HH:MM" format, GMT time zone.
+	StartTime string `json:"startTime,omitempty"`
+}
+
+// GKERecurringTimeWindow defines a recurring maintenance window
+type GKERecurringTimeWindow struct {
+	// StartTime is the start of the first window, in RFC 3339 format, e.g. 2024-01-06T02:00:00Z.
+	StartTime string `json:"startTime,omitempty"`
+	// EndTime is the end of the first window, in RFC 3339 format.
+	EndTime string `json:"endTime,omitempty"`
+	// Recurrence is an RFC 5545 RRULE describing how the window recurs, e.g. FREQ=WEEKLY;BYDAY=SA,SU.
+	Recurrence string `json:"recurrence,omitempty"`
+}
+
+// GKEMaintenanceExclusion defines a named window during which automatic maintenance is restricted
+type GKEMaintenanceExclusion struct {
+	// Name identifies the exclusion and must be unique within the policy.
+	Name string `json:"name,omitempty"`
+	// StartTime is the start of the exclusion, in RFC 3339 format.
+	StartTime string `json:"startTime,omitempty"`
+	// EndTime is the end of the exclusion, in RFC 3339 format.
+	EndTime string `json:"endTime,omitempty"`
+	// Scope restricts the kind of maintenance excluded.
+	// +optional
+	// +kubebuilder:validation:Enum=NO_UPGRADES;NO_MINOR_UPGRADES;NO_MINOR_OR_NODE_UPGRADES
+	Scope string `json:"scope,omitempty"`
+}
+
 // GKEDatabaseEncryption defines database encryption configuration
 type GKEDatabaseEncryption struct {
 	// State represents the encryption state (ENCRYPTED or DECRYPTED)
@@ -433,6 +929,10 @@ type GKEDatabaseEncryption struct {
 	State string `json:"state,omitempty"`
 	// KeyName is the Cloud KMS key to use for encryption
 	KeyName string `json:"keyName,omitempty"`
+	// CurrentState is the encryption state reported by GKE, including pending and failed
+	// transitions. It is ignored when set in the spec.
+	// +optional
+	CurrentState string `json:"currentState,omitempty"`
 }
 
 // GKEBinaryAuthorization defines binary authorization configuration
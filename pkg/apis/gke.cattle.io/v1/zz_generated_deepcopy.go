@@ -22,6 +22,7 @@ limitations under the License.
 package v1
 
 import (
+	genericcondition "github.com/rancher/wrangler/v3/pkg/genericcondition"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -41,6 +42,53 @@ func (in *CMEKConfig) DeepCopy() *CMEKConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEAcceleratorConfig) DeepCopyInto(out *GKEAcceleratorConfig) {
+	*out = *in
+	if in.GpuDriverInstallationConfig != nil {
+		in, out := &in.GpuDriverInstallationConfig, &out.GpuDriverInstallationConfig
+		*out = new(GKEGPUDriverInstallationConfig)
+		**out = **in
+	}
+	if in.GpuSharingConfig != nil {
+		in, out := &in.GpuSharingConfig, &out.GpuSharingConfig
+		*out = new(GKEGPUSharingConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEAcceleratorConfig.
+func (in *GKEAcceleratorConfig) DeepCopy() *GKEAcceleratorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEAcceleratorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEAdditionalPodRangesConfig) DeepCopyInto(out *GKEAdditionalPodRangesConfig) {
+	*out = *in
+	if in.PodRangeNames != nil {
+		in, out := &in.PodRangeNames, &out.PodRangeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEAdditionalPodRangesConfig.
+func (in *GKEAdditionalPodRangesConfig) DeepCopy() *GKEAdditionalPodRangesConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEAdditionalPodRangesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEAutopilotConfig) DeepCopyInto(out *GKEAutopilotConfig) {
 	*out = *in
@@ -57,6 +105,32 @@ func (in *GKEAutopilotConfig) DeepCopy() *GKEAutopilotConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEAutoprovisioningNodePoolDefaults) DeepCopyInto(out *GKEAutoprovisioningNodePoolDefaults) {
+	*out = *in
+	if in.OauthScopes != nil {
+		in, out := &in.OauthScopes, &out.OauthScopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ShieldedInstanceConfig != nil {
+		in, out := &in.ShieldedInstanceConfig, &out.ShieldedInstanceConfig
+		*out = new(GKEShieldedInstanceConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEAutoprovisioningNodePoolDefaults.
+func (in *GKEAutoprovisioningNodePoolDefaults) DeepCopy() *GKEAutoprovisioningNodePoolDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEAutoprovisioningNodePoolDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEBinaryAuthorization) DeepCopyInto(out *GKEBinaryAuthorization) {
 	*out = *in
@@ -73,6 +147,27 @@ func (in *GKEBinaryAuthorization) DeepCopy() *GKEBinaryAuthorization {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEBlueGreenSettings) DeepCopyInto(out *GKEBlueGreenSettings) {
+	*out = *in
+	if in.StandardRolloutPolicy != nil {
+		in, out := &in.StandardRolloutPolicy, &out.StandardRolloutPolicy
+		*out = new(GKEStandardRolloutPolicy)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEBlueGreenSettings.
+func (in *GKEBlueGreenSettings) DeepCopy() *GKEBlueGreenSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEBlueGreenSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKECidrBlock) DeepCopyInto(out *GKECidrBlock) {
 	*out = *in
@@ -108,6 +203,41 @@ func (in *GKEClientCertificateConfig) DeepCopy() *GKEClientCertificateConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEClusterAddons) DeepCopyInto(out *GKEClusterAddons) {
 	*out = *in
+	if in.GcePersistentDiskCsiDriver != nil {
+		in, out := &in.GcePersistentDiskCsiDriver, &out.GcePersistentDiskCsiDriver
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GcpFilestoreCsiDriver != nil {
+		in, out := &in.GcpFilestoreCsiDriver, &out.GcpFilestoreCsiDriver
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GcsFuseCsiDriver != nil {
+		in, out := &in.GcsFuseCsiDriver, &out.GcsFuseCsiDriver
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DNSCacheConfig != nil {
+		in, out := &in.DNSCacheConfig, &out.DNSCacheConfig
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConfigConnectorConfig != nil {
+		in, out := &in.ConfigConnectorConfig, &out.ConfigConnectorConfig
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GkeBackupAgentConfig != nil {
+		in, out := &in.GkeBackupAgentConfig, &out.GkeBackupAgentConfig
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VerticalPodAutoscaling != nil {
+		in, out := &in.VerticalPodAutoscaling, &out.VerticalPodAutoscaling
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -121,13 +251,39 @@ func (in *GKEClusterAddons) DeepCopy() *GKEClusterAddons {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEClusterAutoscaling) DeepCopyInto(out *GKEClusterAutoscaling) {
+	*out = *in
+	if in.ResourceLimits != nil {
+		in, out := &in.ResourceLimits, &out.ResourceLimits
+		*out = make([]GKEResourceLimit, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoprovisioningNodePoolDefaults != nil {
+		in, out := &in.AutoprovisioningNodePoolDefaults, &out.AutoprovisioningNodePoolDefaults
+		*out = new(GKEAutoprovisioningNodePoolDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEClusterAutoscaling.
+func (in *GKEClusterAutoscaling) DeepCopy() *GKEClusterAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEClusterAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEClusterConfig) DeepCopyInto(out *GKEClusterConfig) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -200,7 +356,7 @@ func (in *GKEClusterConfigSpec) DeepCopyInto(out *GKEClusterConfigSpec) {
 	if in.ClusterAddons != nil {
 		in, out := &in.ClusterAddons, &out.ClusterAddons
 		*out = new(GKEClusterAddons)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ClusterIpv4CidrBlock != nil {
 		in, out := &in.ClusterIpv4CidrBlock, &out.ClusterIpv4CidrBlock
@@ -212,6 +368,11 @@ func (in *GKEClusterConfigSpec) DeepCopyInto(out *GKEClusterConfigSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ReleaseChannel != nil {
+		in, out := &in.ReleaseChannel, &out.ReleaseChannel
+		*out = new(string)
+		**out = **in
+	}
 	if in.LoggingService != nil {
 		in, out := &in.LoggingService, &out.LoggingService
 		*out = new(string)
@@ -244,15 +405,20 @@ func (in *GKEClusterConfigSpec) DeepCopyInto(out *GKEClusterConfigSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DatapathProvider != nil {
+		in, out := &in.DatapathProvider, &out.DatapathProvider
+		*out = new(string)
+		**out = **in
+	}
 	if in.PrivateClusterConfig != nil {
 		in, out := &in.PrivateClusterConfig, &out.PrivateClusterConfig
 		*out = new(GKEPrivateClusterConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.IPAllocationPolicy != nil {
 		in, out := &in.IPAllocationPolicy, &out.IPAllocationPolicy
 		*out = new(GKEIPAllocationPolicy)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.MasterAuthorizedNetworksConfig != nil {
 		in, out := &in.MasterAuthorizedNetworksConfig, &out.MasterAuthorizedNetworksConfig
@@ -269,6 +435,11 @@ func (in *GKEClusterConfigSpec) DeepCopyInto(out *GKEClusterConfigSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.MaintenancePolicy != nil {
+		in, out := &in.MaintenancePolicy, &out.MaintenancePolicy
+		*out = new(GKEMaintenancePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AutopilotConfig != nil {
 		in, out := &in.AutopilotConfig, &out.AutopilotConfig
 		*out = new(GKEAutopilotConfig)
@@ -314,6 +485,21 @@ func (in *GKEClusterConfigSpec) DeepCopyInto(out *GKEClusterConfigSpec) {
 		*out = new(GKEIntraNodeVisibilityConfig)
 		**out = **in
 	}
+	if in.ClusterAutoscaling != nil {
+		in, out := &in.ClusterAutoscaling, &out.ClusterAutoscaling
+		*out = new(GKEClusterAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(GKEDNSConfig)
+		**out = **in
+	}
+	if in.ControlPlaneEndpointsConfig != nil {
+		in, out := &in.ControlPlaneEndpointsConfig, &out.ControlPlaneEndpointsConfig
+		*out = new(GKEControlPlaneEndpointsConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -330,6 +516,28 @@ func (in *GKEClusterConfigSpec) DeepCopy() *GKEClusterConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEClusterConfigStatus) DeepCopyInto(out *GKEClusterConfigStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]genericcondition.GenericCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingOperation != nil {
+		in, out := &in.PendingOperation, &out.PendingOperation
+		*out = new(GKEOperation)
+		**out = **in
+	}
+	if in.NodePoolReplacements != nil {
+		in, out := &in.NodePoolReplacements, &out.NodePoolReplacements
+		*out = make(map[string]GKENodePoolReplacement, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AppliedShieldedNodes != nil {
+		in, out := &in.AppliedShieldedNodes, &out.AppliedShieldedNodes
+		*out = new(GKEShieldedNodes)
+		**out = **in
+	}
 	return
 }
 
@@ -343,6 +551,80 @@ func (in *GKEClusterConfigStatus) DeepCopy() *GKEClusterConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEControlPlaneEndpointsConfig) DeepCopyInto(out *GKEControlPlaneEndpointsConfig) {
+	*out = *in
+	if in.DNSEndpointConfig != nil {
+		in, out := &in.DNSEndpointConfig, &out.DNSEndpointConfig
+		*out = new(GKEDNSEndpointConfig)
+		**out = **in
+	}
+	if in.IPEndpointsConfig != nil {
+		in, out := &in.IPEndpointsConfig, &out.IPEndpointsConfig
+		*out = new(GKEIPEndpointsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEControlPlaneEndpointsConfig.
+func (in *GKEControlPlaneEndpointsConfig) DeepCopy() *GKEControlPlaneEndpointsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEControlPlaneEndpointsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEDNSConfig) DeepCopyInto(out *GKEDNSConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEDNSConfig.
+func (in *GKEDNSConfig) DeepCopy() *GKEDNSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEDNSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEDNSEndpointConfig) DeepCopyInto(out *GKEDNSEndpointConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEDNSEndpointConfig.
+func (in *GKEDNSEndpointConfig) DeepCopy() *GKEDNSEndpointConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEDNSEndpointConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEDailyMaintenanceWindow) DeepCopyInto(out *GKEDailyMaintenanceWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEDailyMaintenanceWindow.
+func (in *GKEDailyMaintenanceWindow) DeepCopy() *GKEDailyMaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEDailyMaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEDatabaseEncryption) DeepCopyInto(out *GKEDatabaseEncryption) {
 	*out = *in
@@ -359,9 +641,46 @@ func (in *GKEDatabaseEncryption) DeepCopy() *GKEDatabaseEncryption {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEGPUDriverInstallationConfig) DeepCopyInto(out *GKEGPUDriverInstallationConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEGPUDriverInstallationConfig.
+func (in *GKEGPUDriverInstallationConfig) DeepCopy() *GKEGPUDriverInstallationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEGPUDriverInstallationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEGPUSharingConfig) DeepCopyInto(out *GKEGPUSharingConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEGPUSharingConfig.
+func (in *GKEGPUSharingConfig) DeepCopy() *GKEGPUSharingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEGPUSharingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEIPAllocationPolicy) DeepCopyInto(out *GKEIPAllocationPolicy) {
 	*out = *in
+	if in.AdditionalPodRangesConfig != nil {
+		in, out := &in.AdditionalPodRangesConfig, &out.AdditionalPodRangesConfig
+		*out = new(GKEAdditionalPodRangesConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -375,6 +694,37 @@ func (in *GKEIPAllocationPolicy) DeepCopy() *GKEIPAllocationPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEIPEndpointsConfig) DeepCopyInto(out *GKEIPEndpointsConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GlobalAccess != nil {
+		in, out := &in.GlobalAccess, &out.GlobalAccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AuthorizedNetworksConfig != nil {
+		in, out := &in.AuthorizedNetworksConfig, &out.AuthorizedNetworksConfig
+		*out = new(GKEMasterAuthorizedNetworksConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEIPEndpointsConfig.
+func (in *GKEIPEndpointsConfig) DeepCopy() *GKEIPEndpointsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEIPEndpointsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEIntraNodeVisibilityConfig) DeepCopyInto(out *GKEIntraNodeVisibilityConfig) {
 	*out = *in
@@ -407,6 +757,53 @@ func (in *GKELegacyAbac) DeepCopy() *GKELegacyAbac {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEMaintenanceExclusion) DeepCopyInto(out *GKEMaintenanceExclusion) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEMaintenanceExclusion.
+func (in *GKEMaintenanceExclusion) DeepCopy() *GKEMaintenanceExclusion {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEMaintenanceExclusion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEMaintenancePolicy) DeepCopyInto(out *GKEMaintenancePolicy) {
+	*out = *in
+	if in.DailyMaintenanceWindow != nil {
+		in, out := &in.DailyMaintenanceWindow, &out.DailyMaintenanceWindow
+		*out = new(GKEDailyMaintenanceWindow)
+		**out = **in
+	}
+	if in.RecurringWindow != nil {
+		in, out := &in.RecurringWindow, &out.RecurringWindow
+		*out = new(GKERecurringTimeWindow)
+		**out = **in
+	}
+	if in.MaintenanceExclusions != nil {
+		in, out := &in.MaintenanceExclusions, &out.MaintenanceExclusions
+		*out = make([]GKEMaintenanceExclusion, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEMaintenancePolicy.
+func (in *GKEMaintenancePolicy) DeepCopy() *GKEMaintenancePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEMaintenancePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEMasterAuth) DeepCopyInto(out *GKEMasterAuth) {
 	*out = *in
@@ -455,9 +852,32 @@ func (in *GKEMasterAuthorizedNetworksConfig) DeepCopy() *GKEMasterAuthorizedNetw
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEMasterGlobalAccessConfig) DeepCopyInto(out *GKEMasterGlobalAccessConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEMasterGlobalAccessConfig.
+func (in *GKEMasterGlobalAccessConfig) DeepCopy() *GKEMasterGlobalAccessConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEMasterGlobalAccessConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKENodeConfig) DeepCopyInto(out *GKENodeConfig) {
 	*out = *in
+	if in.Accelerators != nil {
+		in, out := &in.Accelerators, &out.Accelerators
+		*out = make([]GKEAcceleratorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -470,6 +890,13 @@ func (in *GKENodeConfig) DeepCopyInto(out *GKENodeConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResourceLabels != nil {
+		in, out := &in.ResourceLabels, &out.ResourceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make([]string, len(*in))
@@ -503,6 +930,27 @@ func (in *GKENodeConfig) DeepCopy() *GKENodeConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKENodeNetworkConfig) DeepCopyInto(out *GKENodeNetworkConfig) {
+	*out = *in
+	if in.EnablePrivateNodes != nil {
+		in, out := &in.EnablePrivateNodes, &out.EnablePrivateNodes
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKENodeNetworkConfig.
+func (in *GKENodeNetworkConfig) DeepCopy() *GKENodeNetworkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GKENodeNetworkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKENodePoolAutoscaling) DeepCopyInto(out *GKENodePoolAutoscaling) {
 	*out = *in
@@ -537,11 +985,21 @@ func (in *GKENodePoolConfig) DeepCopyInto(out *GKENodePoolConfig) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.MaxPodsConstraint != nil {
 		in, out := &in.MaxPodsConstraint, &out.MaxPodsConstraint
 		*out = new(int64)
 		**out = **in
 	}
+	if in.NetworkConfig != nil {
+		in, out := &in.NetworkConfig, &out.NetworkConfig
+		*out = new(GKENodeNetworkConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Name != nil {
 		in, out := &in.Name, &out.Name
 		*out = new(string)
@@ -557,6 +1015,11 @@ func (in *GKENodePoolConfig) DeepCopyInto(out *GKENodePoolConfig) {
 		*out = new(GKENodePoolManagement)
 		**out = **in
 	}
+	if in.UpgradeSettings != nil {
+		in, out := &in.UpgradeSettings, &out.UpgradeSettings
+		*out = new(GKENodePoolUpgradeSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -586,6 +1049,43 @@ func (in *GKENodePoolManagement) DeepCopy() *GKENodePoolManagement {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKENodePoolReplacement) DeepCopyInto(out *GKENodePoolReplacement) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKENodePoolReplacement.
+func (in *GKENodePoolReplacement) DeepCopy() *GKENodePoolReplacement {
+	if in == nil {
+		return nil
+	}
+	out := new(GKENodePoolReplacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKENodePoolUpgradeSettings) DeepCopyInto(out *GKENodePoolUpgradeSettings) {
+	*out = *in
+	if in.BlueGreenSettings != nil {
+		in, out := &in.BlueGreenSettings, &out.BlueGreenSettings
+		*out = new(GKEBlueGreenSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKENodePoolUpgradeSettings.
+func (in *GKENodePoolUpgradeSettings) DeepCopy() *GKENodePoolUpgradeSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(GKENodePoolUpgradeSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKENodeTaintConfig) DeepCopyInto(out *GKENodeTaintConfig) {
 	*out = *in
@@ -602,9 +1102,30 @@ func (in *GKENodeTaintConfig) DeepCopy() *GKENodeTaintConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEOperation) DeepCopyInto(out *GKEOperation) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEOperation.
+func (in *GKEOperation) DeepCopy() *GKEOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEPrivateClusterConfig) DeepCopyInto(out *GKEPrivateClusterConfig) {
 	*out = *in
+	if in.MasterGlobalAccessConfig != nil {
+		in, out := &in.MasterGlobalAccessConfig, &out.MasterGlobalAccessConfig
+		*out = new(GKEMasterGlobalAccessConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -618,6 +1139,38 @@ func (in *GKEPrivateClusterConfig) DeepCopy() *GKEPrivateClusterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKERecurringTimeWindow) DeepCopyInto(out *GKERecurringTimeWindow) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKERecurringTimeWindow.
+func (in *GKERecurringTimeWindow) DeepCopy() *GKERecurringTimeWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(GKERecurringTimeWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEResourceLimit) DeepCopyInto(out *GKEResourceLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEResourceLimit.
+func (in *GKEResourceLimit) DeepCopy() *GKEResourceLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEResourceLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEShieldedInstanceConfig) DeepCopyInto(out *GKEShieldedInstanceConfig) {
 	*out = *in
@@ -650,6 +1203,22 @@ func (in *GKEShieldedNodes) DeepCopy() *GKEShieldedNodes {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEStandardRolloutPolicy) DeepCopyInto(out *GKEStandardRolloutPolicy) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEStandardRolloutPolicy.
+func (in *GKEStandardRolloutPolicy) DeepCopy() *GKEStandardRolloutPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEStandardRolloutPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GKEWorkloadIdentityConfig) DeepCopyInto(out *GKEWorkloadIdentityConfig) {
 	*out = *in
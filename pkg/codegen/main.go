@@ -40,6 +40,7 @@ func main() {
 
 	gkeClusterConfig := newCRD(&gkev1.GKEClusterConfig{}, func(c crd.CRD) crd.CRD {
 		c.ShortNames = []string{"gkecc"}
+		c = c.WithColumn("Ready", `.status.conditions[?(@.type=="Ready")].status`)
 		return c
 	})
 
@@ -0,0 +1,140 @@
+package gke
+
+import (
+	"fmt"
+	"reflect"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/utils"
+)
+
+// GPU Sharing Strategies
+const (
+	// GPUSharingStrategyTimeSharing shares a GPU between containers by time slicing
+	GPUSharingStrategyTimeSharing = "TIME_SHARING"
+	// GPUSharingStrategyMPS shares a GPU between containers with NVIDIA Multi-Process Service
+	GPUSharingStrategyMPS = "MPS"
+)
+
+// GPU Driver Versions
+const (
+	// GPUDriverVersionInstallationDisabled disables the installation of the GPU drivers
+	GPUDriverVersionInstallationDisabled = "INSTALLATION_DISABLED"
+	// GPUDriverVersionDefault installs the default GPU driver version of the GKE version
+	GPUDriverVersionDefault = "DEFAULT"
+	// GPUDriverVersionLatest installs the latest GPU driver version of the GKE version
+	GPUDriverVersionLatest = "LATEST"
+)
+
+func newAccelerators(accelerators []gkev1.GKEAcceleratorConfig) []*gkeapi.AcceleratorConfig {
+	if len(accelerators) == 0 {
+		return nil
+	}
+	acceleratorConfigs := make([]*gkeapi.AcceleratorConfig, 0, len(accelerators))
+	for _, a := range accelerators {
+		acceleratorConfig := &gkeapi.AcceleratorConfig{
+			AcceleratorCount: a.AcceleratorCount,
+			AcceleratorType:  a.AcceleratorType,
+			GpuPartitionSize: a.GpuPartitionSize,
+		}
+		if a.GpuDriverInstallationConfig != nil {
+			acceleratorConfig.GpuDriverInstallationConfig = &gkeapi.GPUDriverInstallationConfig{
+				GpuDriverVersion: a.GpuDriverInstallationConfig.GpuDriverVersion,
+			}
+		}
+		if a.GpuSharingConfig != nil {
+			acceleratorConfig.GpuSharingConfig = &gkeapi.GPUSharingConfig{
+				GpuSharingStrategy:     a.GpuSharingConfig.GpuSharingStrategy,
+				MaxSharedClientsPerGpu: a.GpuSharingConfig.MaxSharedClientsPerGpu,
+			}
+		}
+		acceleratorConfigs = append(acceleratorConfigs, acceleratorConfig)
+	}
+	return acceleratorConfigs
+}
+
+// BuildAccelerators returns the spec representation of the given GKE accelerators.
+func BuildAccelerators(accelerators []*gkeapi.AcceleratorConfig) []gkev1.GKEAcceleratorConfig {
+	if len(accelerators) == 0 {
+		return nil
+	}
+	acceleratorConfigs := make([]gkev1.GKEAcceleratorConfig, 0, len(accelerators))
+	for _, a := range accelerators {
+		acceleratorConfig := gkev1.GKEAcceleratorConfig{
+			AcceleratorCount: a.AcceleratorCount,
+			AcceleratorType:  a.AcceleratorType,
+			GpuPartitionSize: a.GpuPartitionSize,
+		}
+		if a.GpuDriverInstallationConfig != nil {
+			acceleratorConfig.GpuDriverInstallationConfig = &gkev1.GKEGPUDriverInstallationConfig{
+				GpuDriverVersion: a.GpuDriverInstallationConfig.GpuDriverVersion,
+			}
+		}
+		if a.GpuSharingConfig != nil {
+			acceleratorConfig.GpuSharingConfig = &gkev1.GKEGPUSharingConfig{
+				GpuSharingStrategy:     a.GpuSharingConfig.GpuSharingStrategy,
+				MaxSharedClientsPerGpu: a.GpuSharingConfig.MaxSharedClientsPerGpu,
+			}
+		}
+		acceleratorConfigs = append(acceleratorConfigs, acceleratorConfig)
+	}
+	return acceleratorConfigs
+}
+
+// acceleratorsEqual returns true if the upstream accelerators match the spec. GKE defaults the
+// driver installation config when it is left empty in the spec, so it is then not compared.
+func acceleratorsEqual(accelerators, upstream []gkev1.GKEAcceleratorConfig) bool {
+	if len(accelerators) != len(upstream) {
+		return false
+	}
+	for i := range accelerators {
+		a := accelerators[i]
+		u := upstream[i]
+		if a.GpuDriverInstallationConfig == nil {
+			u.GpuDriverInstallationConfig = nil
+		}
+		if !reflect.DeepEqual(a, u) {
+			return false
+		}
+	}
+	return true
+}
+
+func validateAccelerators(np *gkev1.GKENodePoolConfig, config *gkev1.GKEClusterConfig) error {
+	if np.Config == nil || len(np.Config.Accelerators) == 0 {
+		return nil
+	}
+	npName := utils.StringValue(np.Name)
+	clusterName := config.Spec.ClusterName
+	if IsAutopilot(config) {
+		return fmt.Errorf("accelerators cannot be set for node pool [%s] of autopilot cluster [%s (id: %s)]", npName, clusterName, config.Name)
+	}
+	for _, a := range np.Config.Accelerators {
+		if a.AcceleratorType == "" {
+			return fmt.Errorf("acceleratorType must be set for the accelerators of node pool [%s] in cluster [%s (id: %s)]", npName, clusterName, config.Name)
+		}
+		if a.AcceleratorCount <= 0 {
+			return fmt.Errorf("acceleratorCount must be > 0 for accelerator [%s] of node pool [%s] in cluster [%s (id: %s)]", a.AcceleratorType, npName, clusterName, config.Name)
+		}
+		if sharing := a.GpuSharingConfig; sharing != nil {
+			if sharing.GpuSharingStrategy != GPUSharingStrategyTimeSharing && sharing.GpuSharingStrategy != GPUSharingStrategyMPS {
+				return fmt.Errorf("invalid GPU sharing strategy %q for accelerator [%s] of node pool [%s] in cluster [%s (id: %s)], must be %s or %s",
+					sharing.GpuSharingStrategy, a.AcceleratorType, npName, clusterName, config.Name, GPUSharingStrategyTimeSharing, GPUSharingStrategyMPS)
+			}
+			if sharing.MaxSharedClientsPerGpu <= 0 {
+				return fmt.Errorf("maxSharedClientsPerGpu must be > 0 for accelerator [%s] of node pool [%s] in cluster [%s (id: %s)]", a.AcceleratorType, npName, clusterName, config.Name)
+			}
+		}
+		if driver := a.GpuDriverInstallationConfig; driver != nil {
+			switch driver.GpuDriverVersion {
+			case GPUDriverVersionInstallationDisabled, GPUDriverVersionDefault, GPUDriverVersionLatest:
+			default:
+				return fmt.Errorf("invalid GPU driver version %q for accelerator [%s] of node pool [%s] in cluster [%s (id: %s)], must be %s, %s or %s",
+					driver.GpuDriverVersion, a.AcceleratorType, npName, clusterName, config.Name, GPUDriverVersionInstallationDisabled, GPUDriverVersionDefault, GPUDriverVersionLatest)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package gke
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	gkeapi "google.golang.org/api/container/v1"
+)
+
+var _ = Describe("Accelerators", func() {
+	var (
+		nodePoolName = "test-node-pool"
+		config       *gkev1.GKEClusterConfig
+		np           *gkev1.GKENodePoolConfig
+	)
+
+	BeforeEach(func() {
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName: "test-cluster",
+			},
+		}
+		np = &gkev1.GKENodePoolConfig{
+			Name: &nodePoolName,
+			Config: &gkev1.GKENodeConfig{
+				Accelerators: []gkev1.GKEAcceleratorConfig{
+					{
+						AcceleratorCount: 1,
+						AcceleratorType:  "nvidia-tesla-a100",
+						GpuPartitionSize: "1g.5gb",
+						GpuSharingConfig: &gkev1.GKEGPUSharingConfig{
+							GpuSharingStrategy:     GPUSharingStrategyTimeSharing,
+							MaxSharedClientsPerGpu: 2,
+						},
+						GpuDriverInstallationConfig: &gkev1.GKEGPUDriverInstallationConfig{
+							GpuDriverVersion: GPUDriverVersionLatest,
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("should round-trip the accelerators", func() {
+		accelerators := newAccelerators(np.Config.Accelerators)
+		Expect(accelerators).To(Equal([]*gkeapi.AcceleratorConfig{
+			{
+				AcceleratorCount: 1,
+				AcceleratorType:  "nvidia-tesla-a100",
+				GpuPartitionSize: "1g.5gb",
+				GpuSharingConfig: &gkeapi.GPUSharingConfig{
+					GpuSharingStrategy:     GPUSharingStrategyTimeSharing,
+					MaxSharedClientsPerGpu: 2,
+				},
+				GpuDriverInstallationConfig: &gkeapi.GPUDriverInstallationConfig{
+					GpuDriverVersion: GPUDriverVersionLatest,
+				},
+			},
+		}))
+		Expect(BuildAccelerators(accelerators)).To(Equal(np.Config.Accelerators))
+
+		Expect(newAccelerators(nil)).To(BeNil())
+		Expect(BuildAccelerators(nil)).To(BeNil())
+	})
+
+	It("should compare accelerators", func() {
+		Expect(acceleratorsEqual(np.Config.Accelerators, np.Config.Accelerators)).To(BeTrue())
+		Expect(acceleratorsEqual(np.Config.Accelerators, nil)).To(BeFalse())
+
+		accelerators := np.DeepCopy().Config.Accelerators
+		accelerators[0].GpuDriverInstallationConfig = nil
+		Expect(acceleratorsEqual(accelerators, np.Config.Accelerators)).To(BeTrue())
+
+		accelerators[0].AcceleratorCount = 2
+		Expect(acceleratorsEqual(accelerators, np.Config.Accelerators)).To(BeFalse())
+	})
+
+	It("should validate the accelerators", func() {
+		Expect(validateAccelerators(np, config)).To(Succeed())
+
+		invalidNodePool := np.DeepCopy()
+		invalidNodePool.Config.Accelerators[0].AcceleratorCount = 0
+		Expect(validateAccelerators(invalidNodePool, config)).To(MatchError("acceleratorCount must be > 0 for accelerator [nvidia-tesla-a100] of node pool [test-node-pool] in cluster [test-cluster (id: )]"))
+
+		invalidNodePool = np.DeepCopy()
+		invalidNodePool.Config.Accelerators[0].AcceleratorType = ""
+		Expect(validateAccelerators(invalidNodePool, config)).To(HaveOccurred())
+
+		invalidNodePool = np.DeepCopy()
+		invalidNodePool.Config.Accelerators[0].GpuSharingConfig.GpuSharingStrategy = "SHARED"
+		Expect(validateAccelerators(invalidNodePool, config)).To(HaveOccurred())
+
+		invalidNodePool = np.DeepCopy()
+		invalidNodePool.Config.Accelerators[0].GpuSharingConfig.MaxSharedClientsPerGpu = 0
+		Expect(validateAccelerators(invalidNodePool, config)).To(HaveOccurred())
+
+		invalidNodePool = np.DeepCopy()
+		invalidNodePool.Config.Accelerators[0].GpuDriverInstallationConfig.GpuDriverVersion = "OLDEST"
+		Expect(validateAccelerators(invalidNodePool, config)).To(HaveOccurred())
+
+		autopilotConfig := config.DeepCopy()
+		autopilotConfig.Spec.AutopilotConfig = &gkev1.GKEAutopilotConfig{
+			Enabled: true,
+		}
+		Expect(validateAccelerators(np, autopilotConfig)).To(MatchError("accelerators cannot be set for node pool [test-node-pool] of autopilot cluster [test-cluster (id: )]"))
+	})
+})
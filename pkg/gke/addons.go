@@ -0,0 +1,134 @@
+package gke
+
+import (
+	"fmt"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+)
+
+// NewAddonsConfig returns the GKE addons configuration for the given spec. Optional addons that
+// are not set in the spec are left to the GKE defaults.
+func NewAddonsConfig(addons *gkev1.GKEClusterAddons) *gkeapi.AddonsConfig {
+	addonsConfig := &gkeapi.AddonsConfig{
+		HttpLoadBalancing: &gkeapi.HttpLoadBalancing{
+			Disabled: !addons.HTTPLoadBalancing,
+		},
+		HorizontalPodAutoscaling: &gkeapi.HorizontalPodAutoscaling{
+			Disabled: !addons.HorizontalPodAutoscaling,
+		},
+		NetworkPolicyConfig: &gkeapi.NetworkPolicyConfig{
+			Disabled: !addons.NetworkPolicyConfig,
+		},
+	}
+	setOptionalAddons(addonsConfig, addons, nil)
+	return addonsConfig
+}
+
+// NewVerticalPodAutoscaling returns the GKE vertical pod autoscaling for the given spec, or nil
+// if it is not set.
+func NewVerticalPodAutoscaling(addons *gkev1.GKEClusterAddons) *gkeapi.VerticalPodAutoscaling {
+	if addons.VerticalPodAutoscaling == nil {
+		return nil
+	}
+	return &gkeapi.VerticalPodAutoscaling{
+		Enabled:         *addons.VerticalPodAutoscaling,
+		ForceSendFields: []string{"Enabled"},
+	}
+}
+
+// BuildClusterAddons returns the spec representation of the given GKE addons configuration and
+// vertical pod autoscaling.
+func BuildClusterAddons(addonsConfig *gkeapi.AddonsConfig, vpa *gkeapi.VerticalPodAutoscaling) *gkev1.GKEClusterAddons {
+	addons := &gkev1.GKEClusterAddons{
+		VerticalPodAutoscaling: boolPtr(vpa != nil && vpa.Enabled),
+	}
+	if addonsConfig == nil {
+		addonsConfig = &gkeapi.AddonsConfig{}
+	} else {
+		addons.HTTPLoadBalancing = addonsConfig.HttpLoadBalancing == nil || !addonsConfig.HttpLoadBalancing.Disabled
+		addons.HorizontalPodAutoscaling = addonsConfig.HorizontalPodAutoscaling == nil || !addonsConfig.HorizontalPodAutoscaling.Disabled
+		addons.NetworkPolicyConfig = addonsConfig.NetworkPolicyConfig == nil || !addonsConfig.NetworkPolicyConfig.Disabled
+	}
+	addons.GcePersistentDiskCsiDriver = boolPtr(addonsConfig.GcePersistentDiskCsiDriverConfig != nil && addonsConfig.GcePersistentDiskCsiDriverConfig.Enabled)
+	addons.GcpFilestoreCsiDriver = boolPtr(addonsConfig.GcpFilestoreCsiDriverConfig != nil && addonsConfig.GcpFilestoreCsiDriverConfig.Enabled)
+	addons.GcsFuseCsiDriver = boolPtr(addonsConfig.GcsFuseCsiDriverConfig != nil && addonsConfig.GcsFuseCsiDriverConfig.Enabled)
+	addons.DNSCacheConfig = boolPtr(addonsConfig.DnsCacheConfig != nil && addonsConfig.DnsCacheConfig.Enabled)
+	addons.ConfigConnectorConfig = boolPtr(addonsConfig.ConfigConnectorConfig != nil && addonsConfig.ConfigConnectorConfig.Enabled)
+	addons.GkeBackupAgentConfig = boolPtr(addonsConfig.GkeBackupAgentConfig != nil && addonsConfig.GkeBackupAgentConfig.Enabled)
+	return addons
+}
+
+// setOptionalAddons sets the optional addons of the spec on the GKE addons configuration. If
+// upstream is not nil, only the addons that differ from it are set. It returns true if any
+// addon was set.
+func setOptionalAddons(addonsConfig *gkeapi.AddonsConfig, addons, upstream *gkev1.GKEClusterAddons) bool {
+	if upstream == nil {
+		upstream = &gkev1.GKEClusterAddons{}
+	}
+	changed := false
+	if addonNeedsUpdate(addons.GcePersistentDiskCsiDriver, upstream.GcePersistentDiskCsiDriver) {
+		addonsConfig.GcePersistentDiskCsiDriverConfig = &gkeapi.GcePersistentDiskCsiDriverConfig{
+			Enabled:         *addons.GcePersistentDiskCsiDriver,
+			ForceSendFields: []string{"Enabled"},
+		}
+		changed = true
+	}
+	if addonNeedsUpdate(addons.GcpFilestoreCsiDriver, upstream.GcpFilestoreCsiDriver) {
+		addonsConfig.GcpFilestoreCsiDriverConfig = &gkeapi.GcpFilestoreCsiDriverConfig{
+			Enabled:         *addons.GcpFilestoreCsiDriver,
+			ForceSendFields: []string{"Enabled"},
+		}
+		changed = true
+	}
+	if addonNeedsUpdate(addons.GcsFuseCsiDriver, upstream.GcsFuseCsiDriver) {
+		addonsConfig.GcsFuseCsiDriverConfig = &gkeapi.GcsFuseCsiDriverConfig{
+			Enabled:         *addons.GcsFuseCsiDriver,
+			ForceSendFields: []string{"Enabled"},
+		}
+		changed = true
+	}
+	if addonNeedsUpdate(addons.DNSCacheConfig, upstream.DNSCacheConfig) {
+		addonsConfig.DnsCacheConfig = &gkeapi.DnsCacheConfig{
+			Enabled:         *addons.DNSCacheConfig,
+			ForceSendFields: []string{"Enabled"},
+		}
+		changed = true
+	}
+	if addonNeedsUpdate(addons.ConfigConnectorConfig, upstream.ConfigConnectorConfig) {
+		addonsConfig.ConfigConnectorConfig = &gkeapi.ConfigConnectorConfig{
+			Enabled:         *addons.ConfigConnectorConfig,
+			ForceSendFields: []string{"Enabled"},
+		}
+		changed = true
+	}
+	if addonNeedsUpdate(addons.GkeBackupAgentConfig, upstream.GkeBackupAgentConfig) {
+		addonsConfig.GkeBackupAgentConfig = &gkeapi.GkeBackupAgentConfig{
+			Enabled:         *addons.GkeBackupAgentConfig,
+			ForceSendFields: []string{"Enabled"},
+		}
+		changed = true
+	}
+	return changed
+}
+
+// addonNeedsUpdate returns true if the addon is set in the spec and differs from upstream.
+func addonNeedsUpdate(enabled, upstream *bool) bool {
+	return enabled != nil && (upstream == nil || *enabled != *upstream)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func validateClusterAddons(config *gkev1.GKEClusterConfig) error {
+	addons := config.Spec.ClusterAddons
+	if addons == nil || addons.ConfigConnectorConfig == nil || !*addons.ConfigConnectorConfig {
+		return nil
+	}
+	if config.Spec.WorkloadIdentityConfig == nil || config.Spec.WorkloadIdentityConfig.WorkloadPool == "" {
+		return fmt.Errorf("clusterAddons.configConnectorConfig requires workload identity to be enabled for cluster [%s (id: %s)]", config.Spec.ClusterName, config.Name)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package gke
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	gkeapi "google.golang.org/api/container/v1"
+)
+
+var _ = Describe("ClusterAddons", func() {
+	var (
+		boolTrue  = true
+		boolFalse = false
+		addons    *gkev1.GKEClusterAddons
+	)
+
+	BeforeEach(func() {
+		addons = &gkev1.GKEClusterAddons{
+			HTTPLoadBalancing:          true,
+			HorizontalPodAutoscaling:   true,
+			NetworkPolicyConfig:        false,
+			GcePersistentDiskCsiDriver: &boolTrue,
+			GcpFilestoreCsiDriver:      &boolFalse,
+			GcsFuseCsiDriver:           &boolTrue,
+			DNSCacheConfig:             &boolTrue,
+			ConfigConnectorConfig:      &boolFalse,
+			GkeBackupAgentConfig:       &boolTrue,
+			VerticalPodAutoscaling:     &boolTrue,
+		}
+	})
+
+	It("should round-trip the addons", func() {
+		addonsConfig := NewAddonsConfig(addons)
+		vpa := NewVerticalPodAutoscaling(addons)
+		Expect(addonsConfig.NetworkPolicyConfig.Disabled).To(BeTrue())
+		Expect(addonsConfig.GcpFilestoreCsiDriverConfig.ForceSendFields).To(Equal([]string{"Enabled"}))
+		Expect(BuildClusterAddons(addonsConfig, vpa)).To(Equal(addons))
+	})
+
+	It("should leave unset addons to GKE", func() {
+		addonsConfig := NewAddonsConfig(&gkev1.GKEClusterAddons{})
+		Expect(addonsConfig.GcePersistentDiskCsiDriverConfig).To(BeNil())
+		Expect(addonsConfig.GkeBackupAgentConfig).To(BeNil())
+		Expect(NewVerticalPodAutoscaling(&gkev1.GKEClusterAddons{})).To(BeNil())
+
+		upstream := BuildClusterAddons(nil, nil)
+		Expect(upstream.HTTPLoadBalancing).To(BeFalse())
+		Expect(*upstream.GcePersistentDiskCsiDriver).To(BeFalse())
+		Expect(*upstream.VerticalPodAutoscaling).To(BeFalse())
+	})
+
+	It("should only set the addons that differ from upstream", func() {
+		upstream := addons.DeepCopy()
+		upstream.DNSCacheConfig = &boolFalse
+		addonsConfig := &gkeapi.AddonsConfig{}
+		Expect(setOptionalAddons(addonsConfig, addons, upstream)).To(BeTrue())
+		Expect(addonsConfig).To(Equal(&gkeapi.AddonsConfig{
+			DnsCacheConfig: &gkeapi.DnsCacheConfig{
+				Enabled:         true,
+				ForceSendFields: []string{"Enabled"},
+			},
+		}))
+
+		Expect(setOptionalAddons(&gkeapi.AddonsConfig{}, addons, addons)).To(BeFalse())
+	})
+
+	It("should validate config connector", func() {
+		config := &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName:   "test-cluster",
+				ClusterAddons: addons,
+			},
+		}
+		Expect(validateClusterAddons(config)).To(Succeed())
+
+		config.Spec.ClusterAddons.ConfigConnectorConfig = &boolTrue
+		Expect(validateClusterAddons(config)).To(MatchError("clusterAddons.configConnectorConfig requires workload identity to be enabled for cluster [test-cluster (id: )]"))
+
+		config.Spec.WorkloadIdentityConfig = &gkev1.GKEWorkloadIdentityConfig{
+			WorkloadPool: "test-project.svc.id.goog",
+		}
+		Expect(validateClusterAddons(config)).To(Succeed())
+	})
+})
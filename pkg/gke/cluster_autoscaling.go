@@ -0,0 +1,173 @@
+package gke
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+)
+
+// Autoscaling Profiles
+const (
+	// AutoscalingProfileBalanced is the default profile of the cluster autoscaler
+	AutoscalingProfileBalanced = "BALANCED"
+	// AutoscalingProfileOptimizeUtilization removes underutilized nodes more aggressively
+	AutoscalingProfileOptimizeUtilization = "OPTIMIZE_UTILIZATION"
+)
+
+// Resource Limit Types
+const (
+	// ResourceLimitCPU is the resource type of the cpu limit, in cores
+	ResourceLimitCPU = "cpu"
+	// ResourceLimitMemory is the resource type of the memory limit, in GB
+	ResourceLimitMemory = "memory"
+)
+
+// NewClusterAutoscaling returns the GKE cluster autoscaling for the given spec. Node
+// auto-provisioning is always sent so that it can be disabled.
+func NewClusterAutoscaling(autoscaling *gkev1.GKEClusterAutoscaling) *gkeapi.ClusterAutoscaling {
+	clusterAutoscaling := &gkeapi.ClusterAutoscaling{
+		EnableNodeAutoprovisioning: autoscaling.EnableNodeAutoprovisioning,
+		AutoscalingProfile:         autoscaling.AutoscalingProfile,
+		ForceSendFields:            []string{"EnableNodeAutoprovisioning"},
+	}
+	for _, limit := range autoscaling.ResourceLimits {
+		clusterAutoscaling.ResourceLimits = append(clusterAutoscaling.ResourceLimits, &gkeapi.ResourceLimit{
+			ResourceType: limit.ResourceType,
+			Minimum:      limit.Minimum,
+			Maximum:      limit.Maximum,
+		})
+	}
+	if defaults := autoscaling.AutoprovisioningNodePoolDefaults; defaults != nil {
+		clusterAutoscaling.AutoprovisioningNodePoolDefaults = &gkeapi.AutoprovisioningNodePoolDefaults{
+			ServiceAccount: defaults.ServiceAccount,
+			OauthScopes:    defaults.OauthScopes,
+			BootDiskKmsKey: defaults.BootDiskKmsKey,
+		}
+		if defaults.ShieldedInstanceConfig != nil {
+			clusterAutoscaling.AutoprovisioningNodePoolDefaults.ShieldedInstanceConfig = &gkeapi.ShieldedInstanceConfig{
+				EnableIntegrityMonitoring: defaults.ShieldedInstanceConfig.EnableIntegrityMonitoring,
+				EnableSecureBoot:          defaults.ShieldedInstanceConfig.EnableSecureBoot,
+			}
+		}
+	}
+	return clusterAutoscaling
+}
+
+// BuildClusterAutoscaling returns the spec representation of the given GKE cluster autoscaling.
+func BuildClusterAutoscaling(autoscaling *gkeapi.ClusterAutoscaling) *gkev1.GKEClusterAutoscaling {
+	if autoscaling == nil {
+		return &gkev1.GKEClusterAutoscaling{}
+	}
+	clusterAutoscaling := &gkev1.GKEClusterAutoscaling{
+		EnableNodeAutoprovisioning: autoscaling.EnableNodeAutoprovisioning,
+		AutoscalingProfile:         autoscaling.AutoscalingProfile,
+	}
+	for _, limit := range autoscaling.ResourceLimits {
+		clusterAutoscaling.ResourceLimits = append(clusterAutoscaling.ResourceLimits, gkev1.GKEResourceLimit{
+			ResourceType: limit.ResourceType,
+			Minimum:      limit.Minimum,
+			Maximum:      limit.Maximum,
+		})
+	}
+	if defaults := autoscaling.AutoprovisioningNodePoolDefaults; defaults != nil {
+		clusterAutoscaling.AutoprovisioningNodePoolDefaults = &gkev1.GKEAutoprovisioningNodePoolDefaults{
+			ServiceAccount: defaults.ServiceAccount,
+			OauthScopes:    defaults.OauthScopes,
+			BootDiskKmsKey: defaults.BootDiskKmsKey,
+		}
+		if defaults.ShieldedInstanceConfig != nil {
+			clusterAutoscaling.AutoprovisioningNodePoolDefaults.ShieldedInstanceConfig = &gkev1.GKEShieldedInstanceConfig{
+				EnableIntegrityMonitoring: defaults.ShieldedInstanceConfig.EnableIntegrityMonitoring,
+				EnableSecureBoot:          defaults.ShieldedInstanceConfig.EnableSecureBoot,
+			}
+		}
+	}
+	return clusterAutoscaling
+}
+
+// clusterAutoscalingEqual returns true if the upstream cluster autoscaling matches the spec. Fields
+// left empty in the spec are defaulted by GKE, so they are not compared.
+func clusterAutoscalingEqual(autoscaling, upstream *gkev1.GKEClusterAutoscaling) bool {
+	if upstream == nil {
+		upstream = &gkev1.GKEClusterAutoscaling{}
+	}
+	if autoscaling.EnableNodeAutoprovisioning != upstream.EnableNodeAutoprovisioning {
+		return false
+	}
+	if autoscaling.AutoscalingProfile != "" && autoscaling.AutoscalingProfile != upstream.AutoscalingProfile {
+		return false
+	}
+	if autoscaling.ResourceLimits != nil && !reflect.DeepEqual(sortResourceLimits(autoscaling.ResourceLimits), sortResourceLimits(upstream.ResourceLimits)) {
+		return false
+	}
+
+	defaults := autoscaling.AutoprovisioningNodePoolDefaults
+	if defaults == nil {
+		return true
+	}
+	upstreamDefaults := upstream.AutoprovisioningNodePoolDefaults
+	if upstreamDefaults == nil {
+		upstreamDefaults = &gkev1.GKEAutoprovisioningNodePoolDefaults{}
+	}
+	switch {
+	case defaults.ServiceAccount != "" && !serviceAccountsEqual(defaults.ServiceAccount, upstreamDefaults.ServiceAccount):
+		return false
+	case len(defaults.OauthScopes) != 0 && !stringSetsEqual(defaults.OauthScopes, upstreamDefaults.OauthScopes):
+		return false
+	case defaults.BootDiskKmsKey != "" && defaults.BootDiskKmsKey != upstreamDefaults.BootDiskKmsKey:
+		return false
+	case defaults.ShieldedInstanceConfig != nil && !reflect.DeepEqual(defaults.ShieldedInstanceConfig, upstreamDefaults.ShieldedInstanceConfig):
+		return false
+	}
+	return true
+}
+
+func sortResourceLimits(limits []gkev1.GKEResourceLimit) []gkev1.GKEResourceLimit {
+	sorted := append([]gkev1.GKEResourceLimit{}, limits...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ResourceType < sorted[j].ResourceType
+	})
+	return sorted
+}
+
+func validateClusterAutoscaling(config *gkev1.GKEClusterConfig) error {
+	autoscaling := config.Spec.ClusterAutoscaling
+	if autoscaling == nil {
+		return nil
+	}
+	clusterName := config.Spec.ClusterName
+	if IsAutopilot(config) {
+		return fmt.Errorf(notSupportedForAutopilotError, "clusterAutoscaling", clusterName, config.Name)
+	}
+	switch autoscaling.AutoscalingProfile {
+	case "", AutoscalingProfileBalanced, AutoscalingProfileOptimizeUtilization:
+	default:
+		return fmt.Errorf("invalid autoscaling profile %q for cluster [%s (id: %s)], must be %s or %s", autoscaling.AutoscalingProfile, clusterName, config.Name, AutoscalingProfileBalanced, AutoscalingProfileOptimizeUtilization)
+	}
+
+	if !autoscaling.EnableNodeAutoprovisioning {
+		if len(autoscaling.ResourceLimits) != 0 || autoscaling.AutoprovisioningNodePoolDefaults != nil {
+			return fmt.Errorf("resourceLimits and autoprovisioningNodePoolDefaults require node auto-provisioning to be enabled for cluster [%s (id: %s)]", clusterName, config.Name)
+		}
+		return nil
+	}
+
+	limits := make(map[string]bool, len(autoscaling.ResourceLimits))
+	for _, limit := range autoscaling.ResourceLimits {
+		if limits[limit.ResourceType] {
+			return fmt.Errorf("resource limit [%s] is not unique within the cluster [%s (id: %s)]", limit.ResourceType, clusterName, config.Name)
+		}
+		limits[limit.ResourceType] = true
+		if limit.Minimum < 0 || limit.Maximum <= 0 || limit.Minimum > limit.Maximum {
+			return fmt.Errorf("resource limit [%s] must have a maximum > 0 and a minimum between 0 and the maximum for cluster [%s (id: %s)]", limit.ResourceType, clusterName, config.Name)
+		}
+	}
+	if !limits[ResourceLimitCPU] || !limits[ResourceLimitMemory] {
+		return fmt.Errorf("node auto-provisioning requires %s and %s resource limits for cluster [%s (id: %s)]", ResourceLimitCPU, ResourceLimitMemory, clusterName, config.Name)
+	}
+	return nil
+}
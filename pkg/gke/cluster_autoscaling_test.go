@@ -0,0 +1,116 @@
+package gke
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	gkeapi "google.golang.org/api/container/v1"
+)
+
+var _ = Describe("ClusterAutoscaling", func() {
+	var config *gkev1.GKEClusterConfig
+
+	BeforeEach(func() {
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName: "test-cluster",
+				ClusterAutoscaling: &gkev1.GKEClusterAutoscaling{
+					EnableNodeAutoprovisioning: true,
+					AutoscalingProfile:         AutoscalingProfileOptimizeUtilization,
+					ResourceLimits: []gkev1.GKEResourceLimit{
+						{ResourceType: ResourceLimitCPU, Minimum: 1, Maximum: 64},
+						{ResourceType: ResourceLimitMemory, Minimum: 1, Maximum: 256},
+						{ResourceType: "nvidia-tesla-t4", Maximum: 4},
+					},
+					AutoprovisioningNodePoolDefaults: &gkev1.GKEAutoprovisioningNodePoolDefaults{
+						ServiceAccount: "nodes@test-project.iam.gserviceaccount.com",
+						OauthScopes:    []string{"https://www.googleapis.com/auth/cloud-platform"},
+						ShieldedInstanceConfig: &gkev1.GKEShieldedInstanceConfig{
+							EnableSecureBoot: true,
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("should round-trip the cluster autoscaling", func() {
+		autoscaling := NewClusterAutoscaling(config.Spec.ClusterAutoscaling)
+		Expect(autoscaling).To(Equal(&gkeapi.ClusterAutoscaling{
+			EnableNodeAutoprovisioning: true,
+			AutoscalingProfile:         AutoscalingProfileOptimizeUtilization,
+			ResourceLimits: []*gkeapi.ResourceLimit{
+				{ResourceType: ResourceLimitCPU, Minimum: 1, Maximum: 64},
+				{ResourceType: ResourceLimitMemory, Minimum: 1, Maximum: 256},
+				{ResourceType: "nvidia-tesla-t4", Maximum: 4},
+			},
+			AutoprovisioningNodePoolDefaults: &gkeapi.AutoprovisioningNodePoolDefaults{
+				ServiceAccount: "nodes@test-project.iam.gserviceaccount.com",
+				OauthScopes:    []string{"https://www.googleapis.com/auth/cloud-platform"},
+				ShieldedInstanceConfig: &gkeapi.ShieldedInstanceConfig{
+					EnableSecureBoot: true,
+				},
+			},
+			ForceSendFields: []string{"EnableNodeAutoprovisioning"},
+		}))
+		Expect(BuildClusterAutoscaling(autoscaling)).To(Equal(config.Spec.ClusterAutoscaling))
+
+		Expect(BuildClusterAutoscaling(nil)).To(Equal(&gkev1.GKEClusterAutoscaling{}))
+	})
+
+	It("should compare cluster autoscaling", func() {
+		upstream := config.Spec.ClusterAutoscaling.DeepCopy()
+		upstream.ResourceLimits[0], upstream.ResourceLimits[2] = upstream.ResourceLimits[2], upstream.ResourceLimits[0]
+		upstream.AutoprovisioningNodePoolDefaults.BootDiskKmsKey = "projects/test-project/locations/us-east1/keyRings/ring/cryptoKeys/key"
+		Expect(clusterAutoscalingEqual(config.Spec.ClusterAutoscaling, upstream)).To(BeTrue())
+
+		Expect(clusterAutoscalingEqual(&gkev1.GKEClusterAutoscaling{}, nil)).To(BeTrue())
+		Expect(clusterAutoscalingEqual(&gkev1.GKEClusterAutoscaling{}, upstream)).To(BeFalse())
+
+		upstream.ResourceLimits[1].Maximum = 512
+		Expect(clusterAutoscalingEqual(config.Spec.ClusterAutoscaling, upstream)).To(BeFalse())
+
+		upstream = config.Spec.ClusterAutoscaling.DeepCopy()
+		upstream.AutoscalingProfile = AutoscalingProfileBalanced
+		Expect(clusterAutoscalingEqual(config.Spec.ClusterAutoscaling, upstream)).To(BeFalse())
+
+		upstream = config.Spec.ClusterAutoscaling.DeepCopy()
+		upstream.AutoprovisioningNodePoolDefaults.ServiceAccount = "default"
+		Expect(clusterAutoscalingEqual(config.Spec.ClusterAutoscaling, upstream)).To(BeFalse())
+	})
+
+	It("should validate the cluster autoscaling", func() {
+		Expect(validateClusterAutoscaling(config)).To(Succeed())
+
+		invalidConfig := config.DeepCopy()
+		invalidConfig.Spec.ClusterAutoscaling.AutoscalingProfile = "AGGRESSIVE"
+		Expect(validateClusterAutoscaling(invalidConfig)).To(HaveOccurred())
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.ClusterAutoscaling.ResourceLimits = invalidConfig.Spec.ClusterAutoscaling.ResourceLimits[1:]
+		Expect(validateClusterAutoscaling(invalidConfig)).To(MatchError("node auto-provisioning requires cpu and memory resource limits for cluster [test-cluster (id: )]"))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.ClusterAutoscaling.ResourceLimits[0].Minimum = 128
+		Expect(validateClusterAutoscaling(invalidConfig)).To(HaveOccurred())
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.ClusterAutoscaling.ResourceLimits[2].ResourceType = ResourceLimitCPU
+		Expect(validateClusterAutoscaling(invalidConfig)).To(MatchError("resource limit [cpu] is not unique within the cluster [test-cluster (id: )]"))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.ClusterAutoscaling.EnableNodeAutoprovisioning = false
+		Expect(validateClusterAutoscaling(invalidConfig)).To(MatchError("resourceLimits and autoprovisioningNodePoolDefaults require node auto-provisioning to be enabled for cluster [test-cluster (id: )]"))
+
+		invalidConfig.Spec.ClusterAutoscaling = &gkev1.GKEClusterAutoscaling{
+			AutoscalingProfile: AutoscalingProfileOptimizeUtilization,
+		}
+		Expect(validateClusterAutoscaling(invalidConfig)).To(Succeed())
+
+		invalidConfig.Spec.AutopilotConfig = &gkev1.GKEAutopilotConfig{
+			Enabled: true,
+		}
+		Expect(validateClusterAutoscaling(invalidConfig)).To(MatchError("field [clusterAutoscaling] is not supported for autopilot cluster [test-cluster (id: )]"))
+	})
+})
@@ -0,0 +1,163 @@
+package gke
+
+import (
+	"fmt"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+)
+
+// NewControlPlaneEndpointsConfig returns the GKE control plane endpoints configuration for the
+// given spec. Fields that are not set in the spec are left to GKE.
+func NewControlPlaneEndpointsConfig(endpointsConfig *gkev1.GKEControlPlaneEndpointsConfig) *gkeapi.ControlPlaneEndpointsConfig {
+	return newControlPlaneEndpointsUpdate(endpointsConfig, nil)
+}
+
+// BuildControlPlaneEndpointsConfig returns the spec representation of the given GKE control plane
+// endpoints configuration.
+func BuildControlPlaneEndpointsConfig(endpointsConfig *gkeapi.ControlPlaneEndpointsConfig) *gkev1.GKEControlPlaneEndpointsConfig {
+	if endpointsConfig == nil {
+		return &gkev1.GKEControlPlaneEndpointsConfig{}
+	}
+	newConfig := &gkev1.GKEControlPlaneEndpointsConfig{}
+	if endpointsConfig.DnsEndpointConfig != nil {
+		newConfig.DNSEndpointConfig = &gkev1.GKEDNSEndpointConfig{
+			AllowExternalTraffic: endpointsConfig.DnsEndpointConfig.AllowExternalTraffic,
+		}
+	}
+	if ipEndpoints := endpointsConfig.IpEndpointsConfig; ipEndpoints != nil {
+		newConfig.IPEndpointsConfig = &gkev1.GKEIPEndpointsConfig{
+			Enabled:                  boolPtr(ipEndpoints.Enabled),
+			GlobalAccess:             boolPtr(ipEndpoints.GlobalAccess),
+			AuthorizedNetworksConfig: &gkev1.GKEMasterAuthorizedNetworksConfig{},
+		}
+		if ipEndpoints.AuthorizedNetworksConfig != nil {
+			newConfig.IPEndpointsConfig.AuthorizedNetworksConfig.Enabled = ipEndpoints.AuthorizedNetworksConfig.Enabled
+			for _, b := range ipEndpoints.AuthorizedNetworksConfig.CidrBlocks {
+				newConfig.IPEndpointsConfig.AuthorizedNetworksConfig.CidrBlocks = append(newConfig.IPEndpointsConfig.AuthorizedNetworksConfig.CidrBlocks, &gkev1.GKECidrBlock{
+					CidrBlock:   b.CidrBlock,
+					DisplayName: b.DisplayName,
+				})
+			}
+		}
+	}
+	return newConfig
+}
+
+// DNSEndpoint returns the DNS-based endpoint of the control plane of the given cluster, or an
+// empty string if it does not accept traffic from outside the VPC network.
+func DNSEndpoint(cluster *gkeapi.Cluster) string {
+	if cluster.ControlPlaneEndpointsConfig == nil || cluster.ControlPlaneEndpointsConfig.DnsEndpointConfig == nil {
+		return ""
+	}
+	dnsEndpoint := cluster.ControlPlaneEndpointsConfig.DnsEndpointConfig
+	if !dnsEndpoint.AllowExternalTraffic {
+		return ""
+	}
+	return dnsEndpoint.Endpoint
+}
+
+// newControlPlaneEndpointsUpdate returns the GKE control plane endpoints configuration with the
+// fields of the spec that differ from upstream, or nil if they are all up to date. If upstream is
+// nil, all the fields set in the spec are returned.
+func newControlPlaneEndpointsUpdate(endpointsConfig, upstream *gkev1.GKEControlPlaneEndpointsConfig) *gkeapi.ControlPlaneEndpointsConfig {
+	upstreamDNSEndpoint := &gkev1.GKEDNSEndpointConfig{}
+	upstreamIPEndpoints := &gkev1.GKEIPEndpointsConfig{}
+	if upstream != nil {
+		if upstream.DNSEndpointConfig != nil {
+			upstreamDNSEndpoint = upstream.DNSEndpointConfig
+		}
+		if upstream.IPEndpointsConfig != nil {
+			upstreamIPEndpoints = upstream.IPEndpointsConfig
+		}
+	}
+
+	changed := false
+	newConfig := &gkeapi.ControlPlaneEndpointsConfig{}
+	if dnsEndpoint := endpointsConfig.DNSEndpointConfig; dnsEndpoint != nil && (upstream == nil || dnsEndpoint.AllowExternalTraffic != upstreamDNSEndpoint.AllowExternalTraffic) {
+		newConfig.DnsEndpointConfig = &gkeapi.DNSEndpointConfig{
+			AllowExternalTraffic: dnsEndpoint.AllowExternalTraffic,
+			ForceSendFields:      []string{"AllowExternalTraffic"},
+		}
+		changed = true
+	}
+
+	if ipEndpoints := endpointsConfig.IPEndpointsConfig; ipEndpoints != nil {
+		ipEndpointsConfig := &gkeapi.IPEndpointsConfig{}
+		ipChanged := false
+		if addonNeedsUpdate(ipEndpoints.Enabled, upstreamIPEndpoints.Enabled) {
+			ipEndpointsConfig.Enabled = *ipEndpoints.Enabled
+			ipEndpointsConfig.ForceSendFields = append(ipEndpointsConfig.ForceSendFields, "Enabled")
+			ipChanged = true
+		}
+		if addonNeedsUpdate(ipEndpoints.GlobalAccess, upstreamIPEndpoints.GlobalAccess) {
+			ipEndpointsConfig.GlobalAccess = *ipEndpoints.GlobalAccess
+			ipEndpointsConfig.ForceSendFields = append(ipEndpointsConfig.ForceSendFields, "GlobalAccess")
+			ipChanged = true
+		}
+		if ipEndpoints.AuthorizedNetworksConfig != nil && !authorizedNetworksEqual(ipEndpoints.AuthorizedNetworksConfig, upstreamIPEndpoints.AuthorizedNetworksConfig) {
+			ipEndpointsConfig.AuthorizedNetworksConfig = newMasterAuthorizedNetworksConfig(ipEndpoints.AuthorizedNetworksConfig)
+			ipChanged = true
+		}
+		if ipChanged {
+			newConfig.IpEndpointsConfig = ipEndpointsConfig
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return newConfig
+}
+
+func newMasterAuthorizedNetworksConfig(authorizedNetworks *gkev1.GKEMasterAuthorizedNetworksConfig) *gkeapi.MasterAuthorizedNetworksConfig {
+	newConfig := &gkeapi.MasterAuthorizedNetworksConfig{
+		Enabled:         authorizedNetworks.Enabled,
+		ForceSendFields: []string{"Enabled"},
+	}
+	for _, b := range authorizedNetworks.CidrBlocks {
+		if b == nil {
+			continue
+		}
+		newConfig.CidrBlocks = append(newConfig.CidrBlocks, &gkeapi.CidrBlock{
+			CidrBlock:   b.CidrBlock,
+			DisplayName: b.DisplayName,
+		})
+	}
+	return newConfig
+}
+
+// authorizedNetworksEqual returns true if the upstream authorized networks match the spec. The CIDR
+// blocks are only compared when authorized networks are enabled.
+func authorizedNetworksEqual(authorizedNetworks, upstream *gkev1.GKEMasterAuthorizedNetworksConfig) bool {
+	if upstream == nil {
+		upstream = &gkev1.GKEMasterAuthorizedNetworksConfig{}
+	}
+	if authorizedNetworks.Enabled != upstream.Enabled {
+		return false
+	}
+	return !authorizedNetworks.Enabled || compareCidrBlockPointerSlices(authorizedNetworks.CidrBlocks, upstream.CidrBlocks)
+}
+
+func validateControlPlaneEndpoints(config *gkev1.GKEClusterConfig) error {
+	endpointsConfig := config.Spec.ControlPlaneEndpointsConfig
+	if endpointsConfig == nil || endpointsConfig.IPEndpointsConfig == nil {
+		return nil
+	}
+	clusterName := config.Spec.ClusterName
+	ipEndpoints := endpointsConfig.IPEndpointsConfig
+	if ipEndpoints.Enabled != nil && !*ipEndpoints.Enabled && (endpointsConfig.DNSEndpointConfig == nil || !endpointsConfig.DNSEndpointConfig.AllowExternalTraffic) {
+		return fmt.Errorf("disabling the IP endpoints requires dnsEndpointConfig.allowExternalTraffic to reach the control plane of cluster [%s (id: %s)]", clusterName, config.Name)
+	}
+	if ipEndpoints.AuthorizedNetworksConfig != nil && config.Spec.MasterAuthorizedNetworksConfig != nil &&
+		!authorizedNetworksEqual(ipEndpoints.AuthorizedNetworksConfig, config.Spec.MasterAuthorizedNetworksConfig) {
+		return fmt.Errorf("authorized networks conflict: ipEndpointsConfig.authorizedNetworksConfig must match masterAuthorizedNetworks for cluster [%s (id: %s)]", clusterName, config.Name)
+	}
+	if ipEndpoints.GlobalAccess != nil && config.Spec.PrivateClusterConfig != nil && config.Spec.PrivateClusterConfig.MasterGlobalAccessConfig != nil &&
+		*ipEndpoints.GlobalAccess != config.Spec.PrivateClusterConfig.MasterGlobalAccessConfig.Enabled {
+		return fmt.Errorf("global access conflict: ipEndpointsConfig.globalAccess must match privateClusterConfig.masterGlobalAccessConfig for cluster [%s (id: %s)]", clusterName, config.Name)
+	}
+	return nil
+}
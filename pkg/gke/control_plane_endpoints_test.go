@@ -0,0 +1,107 @@
+package gke
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	gkeapi "google.golang.org/api/container/v1"
+)
+
+var _ = Describe("ControlPlaneEndpoints", func() {
+	var (
+		boolTrue        = true
+		boolFalse       = false
+		endpointsConfig *gkev1.GKEControlPlaneEndpointsConfig
+		config          *gkev1.GKEClusterConfig
+	)
+
+	BeforeEach(func() {
+		endpointsConfig = &gkev1.GKEControlPlaneEndpointsConfig{
+			DNSEndpointConfig: &gkev1.GKEDNSEndpointConfig{
+				AllowExternalTraffic: true,
+			},
+			IPEndpointsConfig: &gkev1.GKEIPEndpointsConfig{
+				Enabled:      &boolTrue,
+				GlobalAccess: &boolFalse,
+				AuthorizedNetworksConfig: &gkev1.GKEMasterAuthorizedNetworksConfig{
+					Enabled: true,
+					CidrBlocks: []*gkev1.GKECidrBlock{
+						{CidrBlock: "10.0.0.0/8", DisplayName: "internal"},
+					},
+				},
+			},
+		}
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName:                 "test-cluster",
+				ControlPlaneEndpointsConfig: endpointsConfig,
+			},
+		}
+	})
+
+	It("should round-trip the control plane endpoints", func() {
+		newConfig := NewControlPlaneEndpointsConfig(endpointsConfig)
+		Expect(newConfig.DnsEndpointConfig.ForceSendFields).To(Equal([]string{"AllowExternalTraffic"}))
+		Expect(newConfig.IpEndpointsConfig.ForceSendFields).To(Equal([]string{"Enabled", "GlobalAccess"}))
+		Expect(BuildControlPlaneEndpointsConfig(newConfig)).To(Equal(endpointsConfig))
+		Expect(NewControlPlaneEndpointsConfig(&gkev1.GKEControlPlaneEndpointsConfig{})).To(BeNil())
+		Expect(BuildControlPlaneEndpointsConfig(nil)).To(Equal(&gkev1.GKEControlPlaneEndpointsConfig{}))
+	})
+
+	It("should only update the endpoints that differ from upstream", func() {
+		upstream := endpointsConfig.DeepCopy()
+		Expect(newControlPlaneEndpointsUpdate(endpointsConfig, upstream)).To(BeNil())
+
+		upstream.IPEndpointsConfig.AuthorizedNetworksConfig.CidrBlocks = nil
+		Expect(newControlPlaneEndpointsUpdate(endpointsConfig, upstream)).To(Equal(&gkeapi.ControlPlaneEndpointsConfig{
+			IpEndpointsConfig: &gkeapi.IPEndpointsConfig{
+				AuthorizedNetworksConfig: &gkeapi.MasterAuthorizedNetworksConfig{
+					Enabled:         true,
+					ForceSendFields: []string{"Enabled"},
+					CidrBlocks: []*gkeapi.CidrBlock{
+						{CidrBlock: "10.0.0.0/8", DisplayName: "internal"},
+					},
+				},
+			},
+		}))
+	})
+
+	It("should return the DNS endpoint only if it allows external traffic", func() {
+		cluster := &gkeapi.Cluster{
+			ControlPlaneEndpointsConfig: &gkeapi.ControlPlaneEndpointsConfig{
+				DnsEndpointConfig: &gkeapi.DNSEndpointConfig{
+					AllowExternalTraffic: true,
+					Endpoint:             "gke-test.us-east1.gke.goog",
+				},
+			},
+		}
+		Expect(DNSEndpoint(cluster)).To(Equal("gke-test.us-east1.gke.goog"))
+
+		cluster.ControlPlaneEndpointsConfig.DnsEndpointConfig.AllowExternalTraffic = false
+		Expect(DNSEndpoint(cluster)).To(BeEmpty())
+		Expect(DNSEndpoint(&gkeapi.Cluster{})).To(BeEmpty())
+	})
+
+	It("should validate the control plane endpoints", func() {
+		Expect(validateControlPlaneEndpoints(config)).To(Succeed())
+
+		invalidConfig := config.DeepCopy()
+		invalidConfig.Spec.ControlPlaneEndpointsConfig.IPEndpointsConfig.Enabled = &boolFalse
+		Expect(validateControlPlaneEndpoints(invalidConfig)).To(Succeed())
+		invalidConfig.Spec.ControlPlaneEndpointsConfig.DNSEndpointConfig = nil
+		Expect(validateControlPlaneEndpoints(invalidConfig)).To(MatchError("disabling the IP endpoints requires dnsEndpointConfig.allowExternalTraffic to reach the control plane of cluster [test-cluster (id: )]"))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.MasterAuthorizedNetworksConfig = &gkev1.GKEMasterAuthorizedNetworksConfig{}
+		Expect(validateControlPlaneEndpoints(invalidConfig)).To(MatchError("authorized networks conflict: ipEndpointsConfig.authorizedNetworksConfig must match masterAuthorizedNetworks for cluster [test-cluster (id: )]"))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.PrivateClusterConfig = &gkev1.GKEPrivateClusterConfig{
+			MasterGlobalAccessConfig: &gkev1.GKEMasterGlobalAccessConfig{
+				Enabled: true,
+			},
+		}
+		Expect(validateControlPlaneEndpoints(invalidConfig)).To(MatchError("global access conflict: ipEndpointsConfig.globalAccess must match privateClusterConfig.masterGlobalAccessConfig for cluster [test-cluster (id: )]"))
+	})
+})
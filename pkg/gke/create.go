@@ -15,10 +15,18 @@ import (
 
 // Errors
 const (
-	cannotBeNilError            = "field [%s] cannot be nil for non-import cluster [%s (id: %s)]"
-	cannotBeNilForNodePoolError = "field [%s] cannot be nil for nodepool [%s] in non-nil cluster [%s (id: %s)]"
+	cannotBeNilError                = "field [%s] cannot be nil for non-import cluster [%s (id: %s)]"
+	cannotBeNilForNodePoolError     = "field [%s] cannot be nil for nodepool [%s] in non-nil cluster [%s (id: %s)]"
+	notSupportedForAutopilotError   = "field [%s] is not supported for autopilot cluster [%s (id: %s)]"
+	notSupportedForDataplaneV2Error = "field [%s] is not supported for Dataplane V2 cluster [%s (id: %s)], which enforces network policies itself"
 )
 
+// IsAutopilot returns true if the config describes an Autopilot cluster. Node pools, network
+// policy and most addons are managed by GKE for Autopilot clusters.
+func IsAutopilot(config *gkev1.GKEClusterConfig) bool {
+	return config.Spec.AutopilotConfig != nil && config.Spec.AutopilotConfig.Enabled
+}
+
 // Create creates an upstream GKE cluster.
 func Create(ctx context.Context, gkeClient services.GKEClusterService, config *gkev1.GKEClusterConfig) error {
 	err := validateCreateRequest(ctx, gkeClient, config)
@@ -92,16 +100,40 @@ func NewClusterCreateRequest(config *gkev1.GKEClusterConfig) *gkeapi.CreateClust
 				ServicesSecondaryRangeName: config.Spec.IPAllocationPolicy.ServicesSecondaryRangeName,
 				SubnetworkName:             config.Spec.IPAllocationPolicy.SubnetworkName,
 				UseIpAliases:               config.Spec.IPAllocationPolicy.UseIPAliases,
+				StackType:                  config.Spec.IPAllocationPolicy.StackType,
+				Ipv6AccessType:             config.Spec.IPAllocationPolicy.IPv6AccessType,
 			},
 		},
 	}
 
-	request.Cluster.AddonsConfig = &gkeapi.AddonsConfig{}
-	request.Cluster.NodePools = make([]*gkeapi.NodePool, 0, len(config.Spec.NodePools))
+	if config.Spec.ReleaseChannel != nil {
+		request.Cluster.ReleaseChannel = &gkeapi.ReleaseChannel{
+			Channel: *config.Spec.ReleaseChannel,
+		}
+	}
 
-	for np := range config.Spec.NodePools {
-		nodePool := newGKENodePoolFromConfig(&config.Spec.NodePools[np], config)
-		request.Cluster.NodePools = append(request.Cluster.NodePools, nodePool)
+	if config.Spec.MaintenancePolicy != nil {
+		request.Cluster.MaintenancePolicy = NewMaintenancePolicy(config.Spec.MaintenancePolicy)
+	}
+
+	if config.Spec.ClusterAutoscaling != nil {
+		request.Cluster.Autoscaling = NewClusterAutoscaling(config.Spec.ClusterAutoscaling)
+	}
+
+	autopilot := IsAutopilot(config)
+	if autopilot {
+		request.Cluster.Autopilot = &gkeapi.Autopilot{
+			Enabled: true,
+		}
+	} else {
+		// the addons are managed by GKE for Autopilot clusters
+		request.Cluster.AddonsConfig = NewAddonsConfig(config.Spec.ClusterAddons)
+		request.Cluster.VerticalPodAutoscaling = NewVerticalPodAutoscaling(config.Spec.ClusterAddons)
+		request.Cluster.NodePools = make([]*gkeapi.NodePool, 0, len(config.Spec.NodePools))
+		for np := range config.Spec.NodePools {
+			nodePool := newGKENodePoolFromConfig(&config.Spec.NodePools[np], config)
+			request.Cluster.NodePools = append(request.Cluster.NodePools, nodePool)
+		}
 	}
 
 	if config.Spec.MasterAuthorizedNetworksConfig != nil {
@@ -125,18 +157,26 @@ func NewClusterCreateRequest(config *gkev1.GKEClusterConfig) *gkeapi.CreateClust
 		request.Cluster.Subnetwork = *config.Spec.Subnetwork
 	}
 
-	if config.Spec.NetworkPolicyEnabled != nil {
+	// Autopilot clusters always use Dataplane V2, which enforces network policies itself
+	if config.Spec.NetworkPolicyEnabled != nil && !autopilot {
 		request.Cluster.NetworkPolicy = &gkeapi.NetworkPolicy{
 			Enabled: *config.Spec.NetworkPolicyEnabled,
 		}
 	}
 
+	request.Cluster.NetworkConfig = &gkeapi.NetworkConfig{}
+	if config.Spec.DatapathProvider != nil && !autopilot {
+		request.Cluster.NetworkConfig.DatapathProvider = *config.Spec.DatapathProvider
+	}
+	if config.Spec.DNSConfig != nil {
+		request.Cluster.NetworkConfig.DnsConfig = NewDNSConfig(config.Spec.DNSConfig)
+	}
+
 	if config.Spec.PrivateClusterConfig != nil && config.Spec.PrivateClusterConfig.EnablePrivateNodes {
-		request.Cluster.PrivateClusterConfig = &gkeapi.PrivateClusterConfig{
-			EnablePrivateEndpoint: config.Spec.PrivateClusterConfig.EnablePrivateEndpoint,
-			EnablePrivateNodes:    config.Spec.PrivateClusterConfig.EnablePrivateNodes,
-			MasterIpv4CidrBlock:   config.Spec.PrivateClusterConfig.MasterIpv4CidrBlock,
-		}
+		request.Cluster.PrivateClusterConfig = NewPrivateClusterConfig(config.Spec.PrivateClusterConfig)
+	}
+	if config.Spec.ControlPlaneEndpointsConfig != nil {
+		request.Cluster.ControlPlaneEndpointsConfig = NewControlPlaneEndpointsConfig(config.Spec.ControlPlaneEndpointsConfig)
 	}
 
 	// Security Controls Implementation
@@ -156,11 +196,9 @@ func NewClusterCreateRequest(config *gkev1.GKEClusterConfig) *gkeapi.CreateClust
 		}
 	}
 
-	// Shielded Nodes
-	if config.Spec.ShieldedNodes != nil {
-		request.Cluster.ShieldedNodes = &gkeapi.ShieldedNodes{
-			Enabled: config.Spec.ShieldedNodes.Enabled,
-		}
+	// Shielded Nodes, always enabled for Autopilot clusters
+	if config.Spec.ShieldedNodes != nil && !autopilot {
+		request.Cluster.ShieldedNodes = NewShieldedNodes(config.Spec.ShieldedNodes)
 	}
 
 	// Workload Identity
@@ -193,9 +231,7 @@ func NewClusterCreateRequest(config *gkev1.GKEClusterConfig) *gkeapi.CreateClust
 
 	// Intra-node Visibility
 	if config.Spec.IntraNodeVisibilityConfig != nil {
-		request.Cluster.NetworkConfig = &gkeapi.NetworkConfig{
-			EnableIntraNodeVisibility: config.Spec.IntraNodeVisibilityConfig.Enabled,
-		}
+		request.Cluster.NetworkConfig.EnableIntraNodeVisibility = config.Spec.IntraNodeVisibilityConfig.Enabled
 	}
 
 	return request
@@ -216,7 +252,7 @@ func validateCreateRequest(ctx context.Context, gkeClient services.GKEClusterSer
 		return fmt.Errorf("cluster name is required")
 	}
 
-	if len(config.Spec.NodePools) != 0 && config.Spec.AutopilotConfig != nil && config.Spec.AutopilotConfig.Enabled {
+	if len(config.Spec.NodePools) != 0 && IsAutopilot(config) {
 		return fmt.Errorf("cannot create node pools for autopilot clusters")
 	}
 
@@ -235,6 +271,52 @@ func validateCreateRequest(ctx context.Context, gkeClient services.GKEClusterSer
 				return fmt.Errorf("minNodeCount in the NodePool [%s] must be >= 1 and <= maxNodeCount within the cluster [%s (id: %s)]", utils.StringValue(np.Name), config.Spec.ClusterName, config.Name)
 			}
 		}
+
+		if InReleaseChannel(&config.Spec) && np.Management != nil && !np.Management.AutoUpgrade {
+			return fmt.Errorf("autoUpgrade must be enabled in the NodePool [%s] for cluster [%s (id: %s)] enrolled in release channel %s", utils.StringValue(np.Name), config.Spec.ClusterName, config.Name, *config.Spec.ReleaseChannel)
+		}
+	}
+
+	if config.Spec.ReleaseChannel != nil {
+		switch *config.Spec.ReleaseChannel {
+		case ReleaseChannelUnspecified, ReleaseChannelRapid, ReleaseChannelRegular, ReleaseChannelStable, ReleaseChannelExtended:
+		default:
+			return fmt.Errorf("invalid release channel %q for cluster [%s (id: %s)], must be one of %s, %s, %s, %s or %s", *config.Spec.ReleaseChannel, config.Spec.ClusterName, config.Name,
+				ReleaseChannelRapid, ReleaseChannelRegular, ReleaseChannelStable, ReleaseChannelExtended, ReleaseChannelUnspecified)
+		}
+	}
+
+	if err := validateMaintenancePolicy(config); err != nil {
+		return err
+	}
+
+	if err := validateClusterAutoscaling(config); err != nil {
+		return err
+	}
+
+	if err := validateClusterAddons(config); err != nil {
+		return err
+	}
+
+	if err := validateWorkloadIdentity(config); err != nil {
+		return err
+	}
+
+	if err := validateDatapathProvider(config); err != nil {
+		return err
+	}
+
+	if err := validateDNSConfig(config); err != nil {
+		return err
+	}
+
+	if err := validateDatabaseEncryption(config); err != nil {
+		return err
+	}
+
+	datapathProvider := utils.StringValue(config.Spec.DatapathProvider)
+	if err := validateStackType(config, IsAutopilot(config) || datapathProvider == DatapathProviderAdvanced); err != nil {
+		return err
 	}
 
 	if config.Spec.CustomerManagedEncryptionKey != nil {
@@ -290,13 +372,22 @@ func validateCreateRequest(ctx context.Context, gkeClient services.GKEClusterSer
 	if config.Spec.PrivateClusterConfig == nil {
 		return fmt.Errorf(cannotBeNilError, "privateClusterConfig", config.Spec.ClusterName, config.Name)
 	}
-	if config.Spec.PrivateClusterConfig.EnablePrivateEndpoint && !config.Spec.PrivateClusterConfig.EnablePrivateNodes {
-		return fmt.Errorf("private endpoint requires private nodes for cluster [%s (id: %s)]", config.Spec.ClusterName, config.Name)
+	if err := validatePrivateClusterConfig(config); err != nil {
+		return err
+	}
+	if err := validateControlPlaneEndpoints(config); err != nil {
+		return err
 	}
 	if config.Spec.MasterAuthorizedNetworksConfig == nil {
 		return fmt.Errorf(cannotBeNilError, "masterAuthorizedNetworksConfig", config.Spec.ClusterName, config.Name)
 	}
 
+	if IsAutopilot(config) {
+		if err := validateAutopilotCreateRequest(config); err != nil {
+			return err
+		}
+	}
+
 	// Validate IP allocation policy to prevent CIDR conflicts
 	if config.Spec.IPAllocationPolicy != nil {
 		// Validate cluster IP allocation
@@ -318,6 +409,11 @@ func validateCreateRequest(ctx context.Context, gkeClient services.GKEClusterSer
 		if config.Spec.ClusterIpv4CidrBlock != nil && config.Spec.IPAllocationPolicy.ClusterSecondaryRangeName != "" {
 			return fmt.Errorf("cluster CIDR conflict: cannot specify both top-level clusterIpv4Cidr and ipAllocationPolicy.clusterSecondaryRangeName for cluster [%s (id: %s)]. When using secondary ranges, omit the top-level clusterIpv4Cidr field", config.Spec.ClusterName, config.Name)
 		}
+
+		// Validate that additional pod ranges do not reuse the cluster or services ranges
+		if err := validateAdditionalPodRanges(config); err != nil {
+			return err
+		}
 	}
 
 	for np := range config.Spec.NodePools {
@@ -329,6 +425,86 @@ func validateCreateRequest(ctx context.Context, gkeClient services.GKEClusterSer
 	return nil
 }
 
+// validateAutopilotCreateRequest rejects fields that are managed by GKE for Autopilot clusters
+// and would otherwise be silently ignored.
+func validateAutopilotCreateRequest(config *gkev1.GKEClusterConfig) error {
+	clusterName := config.Spec.ClusterName
+	if *config.Spec.NetworkPolicyEnabled {
+		return fmt.Errorf(notSupportedForAutopilotError, "networkPolicyEnabled", clusterName, config.Name)
+	}
+	if config.Spec.ClusterAddons.NetworkPolicyConfig {
+		return fmt.Errorf(notSupportedForAutopilotError, "clusterAddons.networkPolicyConfig", clusterName, config.Name)
+	}
+	if config.Spec.CustomerManagedEncryptionKey != nil {
+		return fmt.Errorf(notSupportedForAutopilotError, "customerManagedEncryptionKey", clusterName, config.Name)
+	}
+	if err := validateShieldedNodes(config); err != nil {
+		return err
+	}
+	if config.Spec.LegacyAbac != nil && config.Spec.LegacyAbac.Enabled {
+		return fmt.Errorf(notSupportedForAutopilotError, "legacyAbac", clusterName, config.Name)
+	}
+	if config.Spec.DatapathProvider != nil && *config.Spec.DatapathProvider == DatapathProviderLegacy {
+		return fmt.Errorf(notSupportedForAutopilotError, "datapathProvider", clusterName, config.Name)
+	}
+	return nil
+}
+
+// validateStackType checks that dual-stack clusters are VPC-native and use Dataplane V2, as
+// required by GKE.
+func validateStackType(config *gkev1.GKEClusterConfig, dataplaneV2 bool) error {
+	policy := config.Spec.IPAllocationPolicy
+	if policy == nil {
+		return nil
+	}
+	clusterName := config.Spec.ClusterName
+	switch policy.StackType {
+	case "", StackTypeIPv4:
+		if policy.IPv6AccessType != "" {
+			return fmt.Errorf("ipv6AccessType requires the %s stack type for cluster [%s (id: %s)]", StackTypeIPv4IPv6, clusterName, config.Name)
+		}
+		return nil
+	case StackTypeIPv4IPv6:
+	default:
+		return fmt.Errorf("invalid stack type %q for cluster [%s (id: %s)], must be %s or %s", policy.StackType, clusterName, config.Name, StackTypeIPv4, StackTypeIPv4IPv6)
+	}
+	switch policy.IPv6AccessType {
+	case "", IPv6AccessTypeInternal, IPv6AccessTypeExternal:
+	default:
+		return fmt.Errorf("invalid IPv6 access type %q for cluster [%s (id: %s)], must be %s or %s", policy.IPv6AccessType, clusterName, config.Name, IPv6AccessTypeInternal, IPv6AccessTypeExternal)
+	}
+	if !policy.UseIPAliases {
+		return fmt.Errorf("the %s stack type requires a VPC-native cluster with useIpAliases for cluster [%s (id: %s)]", StackTypeIPv4IPv6, clusterName, config.Name)
+	}
+	if !dataplaneV2 {
+		return fmt.Errorf("the %s stack type requires the %s datapath provider for cluster [%s (id: %s)]", StackTypeIPv4IPv6, DatapathProviderAdvanced, clusterName, config.Name)
+	}
+	return nil
+}
+
+// validateDatapathProvider rejects Calico network policy enforcement for Dataplane V2 clusters,
+// which enforce network policies themselves.
+func validateDatapathProvider(config *gkev1.GKEClusterConfig) error {
+	if config.Spec.DatapathProvider == nil {
+		return nil
+	}
+	clusterName := config.Spec.ClusterName
+	switch *config.Spec.DatapathProvider {
+	case "", DatapathProviderLegacy:
+		return nil
+	case DatapathProviderAdvanced:
+	default:
+		return fmt.Errorf("invalid datapath provider %q for cluster [%s (id: %s)], must be %s or %s", *config.Spec.DatapathProvider, clusterName, config.Name, DatapathProviderLegacy, DatapathProviderAdvanced)
+	}
+	if config.Spec.NetworkPolicyEnabled != nil && *config.Spec.NetworkPolicyEnabled {
+		return fmt.Errorf(notSupportedForDataplaneV2Error, "networkPolicyEnabled", clusterName, config.Name)
+	}
+	if config.Spec.ClusterAddons != nil && config.Spec.ClusterAddons.NetworkPolicyConfig {
+		return fmt.Errorf(notSupportedForDataplaneV2Error, "clusterAddons.networkPolicyConfig", clusterName, config.Name)
+	}
+	return nil
+}
+
 func validateNodePoolCreateRequest(np *gkev1.GKENodePoolConfig, config *gkev1.GKEClusterConfig) error {
 	clusterErr := cannotBeNilError
 	nodePoolErr := cannotBeNilForNodePoolError
@@ -336,6 +512,9 @@ func validateNodePoolCreateRequest(np *gkev1.GKENodePoolConfig, config *gkev1.GK
 	if np.Name == nil {
 		return fmt.Errorf(clusterErr, "nodePool.name", clusterName, config.Name)
 	}
+	if IsAutopilot(config) {
+		return fmt.Errorf("cannot create node pool [%s] for autopilot cluster [%s (id: %s)]", *np.Name, clusterName, config.Name)
+	}
 	if np.Version == nil {
 		return fmt.Errorf(nodePoolErr, "version", *np.Name, clusterName, config.Name)
 	}
@@ -359,7 +538,18 @@ func validateNodePoolCreateRequest(np *gkev1.GKENodePoolConfig, config *gkev1.GK
 	if np.Config.ServiceAccount != "" && np.Config.ServiceAccount != "default" && !rxEmail.MatchString(np.Config.ServiceAccount) {
 		return fmt.Errorf("field [%s] must either be an empty string, 'default' or set to a valid email address for nodepool [%s] in non-nil cluster [%s (id: %s)]", "serviceAccount", *np.Name, clusterName, config.Name)
 	}
-	return nil
+	for _, t := range np.Config.Taints {
+		if !isValidTaintEffect(t.Effect) {
+			return fmt.Errorf("invalid effect %q for taint [%s] in node pool [%s] of cluster [%s (id: %s)]", t.Effect, t.Key, *np.Name, clusterName, config.Name)
+		}
+	}
+	if err := ValidateNodePoolConfig(np, config); err != nil {
+		return err
+	}
+	if err := validateNodeNetworkConfig(np, config); err != nil {
+		return err
+	}
+	return validateUpgradeSettings(np, config)
 }
 
 func newNodePoolCreateRequest(np *gkev1.GKENodePoolConfig, config *gkev1.GKEClusterConfig) (*gkeapi.CreateNodePoolRequest, error) {
@@ -372,21 +562,16 @@ func newNodePoolCreateRequest(np *gkev1.GKENodePoolConfig, config *gkev1.GKEClus
 }
 
 func newGKENodePoolFromConfig(np *gkev1.GKENodePoolConfig, config *gkev1.GKEClusterConfig) *gkeapi.NodePool {
-	taints := make([]*gkeapi.NodeTaint, 0, len(np.Config.Taints))
-	for _, t := range np.Config.Taints {
-		taints = append(taints, &gkeapi.NodeTaint{
-			Effect: t.Effect,
-			Key:    t.Key,
-			Value:  t.Value,
-		})
-	}
 	ret := &gkeapi.NodePool{
 		Name: *np.Name,
 		Autoscaling: &gkeapi.NodePoolAutoscaling{
 			Enabled: np.Autoscaling.Enabled,
 		},
 		InitialNodeCount: *np.InitialNodeCount,
+		Locations:        np.Locations,
+		NetworkConfig:    newNodeNetworkConfig(np.NetworkConfig),
 		Config: &gkeapi.NodeConfig{
+			Accelerators:   newAccelerators(np.Config.Accelerators),
 			DiskSizeGb:     np.Config.DiskSizeGb,
 			DiskType:       np.Config.DiskType,
 			ImageType:      np.Config.ImageType,
@@ -395,8 +580,10 @@ func newGKENodePoolFromConfig(np *gkev1.GKENodePoolConfig, config *gkev1.GKEClus
 			MachineType:    np.Config.MachineType,
 			OauthScopes:    np.Config.OauthScopes,
 			Preemptible:    np.Config.Preemptible,
+			ResourceLabels: np.Config.ResourceLabels,
+			Spot:           np.Config.Spot,
 			Tags:           np.Config.Tags,
-			Taints:         taints,
+			Taints:         newNodeTaints(np.Config.Taints),
 			ServiceAccount: np.Config.ServiceAccount,
 		},
 		Version: *np.Version,
@@ -404,6 +591,7 @@ func newGKENodePoolFromConfig(np *gkev1.GKENodePoolConfig, config *gkev1.GKEClus
 			AutoRepair:  np.Management.AutoRepair,
 			AutoUpgrade: np.Management.AutoUpgrade,
 		},
+		UpgradeSettings: newUpgradeSettings(np.UpgradeSettings),
 	}
 	
 	// Only set autoscaling node counts when autoscaling is enabled
@@ -412,30 +600,13 @@ func newGKENodePoolFromConfig(np *gkev1.GKENodePoolConfig, config *gkev1.GKEClus
 		ret.Autoscaling.MinNodeCount = np.Autoscaling.MinNodeCount
 	}
 	
-	if config.Spec.CustomerManagedEncryptionKey != nil &&
-		config.Spec.CustomerManagedEncryptionKey.RingName != "" &&
-		config.Spec.CustomerManagedEncryptionKey.KeyName != "" {
-		ret.Config.BootDiskKmsKey = BootDiskRRN(
-			config.Spec.ProjectID,
-			Location(config.Spec.Region, config.Spec.Zone),
-			config.Spec.CustomerManagedEncryptionKey.RingName,
-			config.Spec.CustomerManagedEncryptionKey.KeyName,
-		)
-	}
-	
-	// Check for node-pool-specific boot disk KMS key
-	if np.Config.BootDiskKmsKey != "" {
-		ret.Config.BootDiskKmsKey = np.Config.BootDiskKmsKey
-	}
+	ret.Config.BootDiskKmsKey = nodePoolBootDiskKmsKey(np, config)
 	
 	// Security Controls for Node Pools
 	
 	// Shielded Instance Configuration (Integrity Monitoring and Secure Boot)
 	if np.Config.ShieldedInstanceConfig != nil {
-		ret.Config.ShieldedInstanceConfig = &gkeapi.ShieldedInstanceConfig{
-			EnableIntegrityMonitoring: np.Config.ShieldedInstanceConfig.EnableIntegrityMonitoring,
-			EnableSecureBoot:         np.Config.ShieldedInstanceConfig.EnableSecureBoot,
-		}
+		ret.Config.ShieldedInstanceConfig = NewShieldedInstanceConfig(np.Config.ShieldedInstanceConfig)
 	}
 
 	// Workload Metadata Configuration (for GKE Metadata Server)
@@ -20,6 +20,7 @@ var _ = Describe("CreateCluster", func() {
 		subnetworkName     = "test-subnetwork"
 		emptyString        = ""
 		boolTrue           = true
+		boolFalse          = false
 		nodePoolName       = "test-node-pool"
 		initialNodeCount   = int64(3)
 		maxPodsConstraint  = int64(110)
@@ -156,41 +157,335 @@ var _ = Describe("CreateCluster", func() {
 	})
 
 	It("should successfully create autopilot cluster", func() {
-		config.Spec.ClusterName = "test-autopilot-cluster"
-		config.Spec.AutopilotConfig = &gkev1.GKEAutopilotConfig{
+		autopilotConfig := config.DeepCopy()
+		autopilotConfig.Spec.ClusterName = "test-autopilot-cluster"
+		autopilotConfig.Spec.NetworkPolicyEnabled = &boolFalse
+		autopilotConfig.Spec.CustomerManagedEncryptionKey = nil
+		autopilotConfig.Spec.AutopilotConfig = &gkev1.GKEAutopilotConfig{
 			Enabled: true,
 		}
 
-		createClusterRequest := NewClusterCreateRequest(config)
+		createClusterRequest := NewClusterCreateRequest(autopilotConfig)
+		Expect(createClusterRequest.Cluster.Autopilot).To(Equal(&gkeapi.Autopilot{Enabled: true}))
+		Expect(createClusterRequest.Cluster.NodePools).To(BeEmpty())
+		Expect(createClusterRequest.Cluster.NetworkPolicy).To(BeNil())
+		Expect(createClusterRequest.Cluster.ShieldedNodes).To(BeNil())
+		Expect(createClusterRequest.Cluster.AddonsConfig).To(BeNil())
+
 		clusterServiceMock.EXPECT().
 			ClusterCreate(
 				ctx,
-				LocationRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone)),
+				LocationRRN(autopilotConfig.Spec.ProjectID, Location(autopilotConfig.Spec.Region, autopilotConfig.Spec.Zone)),
 				createClusterRequest).
 			Return(&gkeapi.Operation{}, nil)
 
 		clusterServiceMock.EXPECT().
 			ClusterList(
 				ctx,
-				LocationRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone))).
+				LocationRRN(autopilotConfig.Spec.ProjectID, Location(autopilotConfig.Spec.Region, autopilotConfig.Spec.Zone))).
 			Return(&gkeapi.ListClustersResponse{}, nil)
 
-		err := Create(ctx, clusterServiceMock, config)
+		err := Create(ctx, clusterServiceMock, autopilotConfig)
 		Expect(err).ToNot(HaveOccurred())
 
 		clusterServiceMock.EXPECT().
 			ClusterGet(
 				ctx,
-				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone),
-					config.Spec.ClusterName)).
+				ClusterRRN(autopilotConfig.Spec.ProjectID, Location(autopilotConfig.Spec.Region, autopilotConfig.Spec.Zone),
+					autopilotConfig.Spec.ClusterName)).
 			Return(
 				&gkeapi.Cluster{
 					Name: "test-autopilot-cluster",
 				}, nil)
 
-		managedCluster, err := GetCluster(ctx, clusterServiceMock, &config.Spec)
+		managedCluster, err := GetCluster(ctx, clusterServiceMock, &autopilotConfig.Spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(managedCluster.Name).To(Equal(autopilotConfig.Spec.ClusterName))
+	})
+
+	It("should fail to create autopilot cluster with fields autopilot ignores", func() {
+		autopilotConfig := config.DeepCopy()
+		autopilotConfig.Spec.ClusterName = "test-autopilot-cluster"
+		autopilotConfig.Spec.NetworkPolicyEnabled = &boolFalse
+		autopilotConfig.Spec.CustomerManagedEncryptionKey = nil
+		autopilotConfig.Spec.AutopilotConfig = &gkev1.GKEAutopilotConfig{
+			Enabled: true,
+		}
+
+		clusterServiceMock.EXPECT().
+			ClusterList(
+				ctx,
+				LocationRRN(autopilotConfig.Spec.ProjectID, Location(autopilotConfig.Spec.Region, autopilotConfig.Spec.Zone))).
+			Return(&gkeapi.ListClustersResponse{}, nil).
+			Times(4)
+
+		networkPolicyConfig := autopilotConfig.DeepCopy()
+		networkPolicyConfig.Spec.NetworkPolicyEnabled = &boolTrue
+		err := Create(ctx, clusterServiceMock, networkPolicyConfig)
+		Expect(err).To(MatchError("field [networkPolicyEnabled] is not supported for autopilot cluster [test-autopilot-cluster (id: )]"))
+
+		networkPolicyAddonConfig := autopilotConfig.DeepCopy()
+		networkPolicyAddonConfig.Spec.ClusterAddons.NetworkPolicyConfig = true
+		err = Create(ctx, clusterServiceMock, networkPolicyAddonConfig)
+		Expect(err).To(MatchError("field [clusterAddons.networkPolicyConfig] is not supported for autopilot cluster [test-autopilot-cluster (id: )]"))
+
+		shieldedNodesConfig := autopilotConfig.DeepCopy()
+		shieldedNodesConfig.Spec.ShieldedNodes = &gkev1.GKEShieldedNodes{
+			Enabled: false,
+		}
+		err = Create(ctx, clusterServiceMock, shieldedNodesConfig)
+		Expect(err).To(MatchError("field [shieldedNodes] is not supported for autopilot cluster [test-autopilot-cluster (id: )]"))
+
+		legacyAbacConfig := autopilotConfig.DeepCopy()
+		legacyAbacConfig.Spec.LegacyAbac = &gkev1.GKELegacyAbac{
+			Enabled: true,
+		}
+		err = Create(ctx, clusterServiceMock, legacyAbacConfig)
+		Expect(err).To(MatchError("field [legacyAbac] is not supported for autopilot cluster [test-autopilot-cluster (id: )]"))
+	})
+
+	It("should create cluster in a release channel", func() {
+		channelConfig := config.DeepCopy()
+		channelConfig.Spec.ClusterName = "test-channel-cluster"
+		channelConfig.Spec.CustomerManagedEncryptionKey = nil
+		releaseChannel := ReleaseChannelRapid
+		channelConfig.Spec.ReleaseChannel = &releaseChannel
+
+		createClusterRequest := NewClusterCreateRequest(channelConfig)
+		Expect(createClusterRequest.Cluster.ReleaseChannel).To(Equal(&gkeapi.ReleaseChannel{Channel: ReleaseChannelRapid}))
+
+		clusterServiceMock.EXPECT().
+			ClusterCreate(
+				ctx,
+				LocationRRN(channelConfig.Spec.ProjectID, Location(channelConfig.Spec.Region, channelConfig.Spec.Zone)),
+				createClusterRequest).
+			Return(&gkeapi.Operation{}, nil)
+
+		clusterServiceMock.EXPECT().
+			ClusterList(
+				ctx,
+				LocationRRN(channelConfig.Spec.ProjectID, Location(channelConfig.Spec.Region, channelConfig.Spec.Zone))).
+			Return(&gkeapi.ListClustersResponse{}, nil)
+
+		err := Create(ctx, clusterServiceMock, channelConfig)
 		Expect(err).ToNot(HaveOccurred())
-		Expect(managedCluster.Name).To(Equal(config.Spec.ClusterName))
+	})
+
+	It("should create cluster with node auto-provisioning", func() {
+		autoscalingConfig := config.DeepCopy()
+		autoscalingConfig.Spec.ClusterName = "test-autoscaling-cluster"
+		autoscalingConfig.Spec.CustomerManagedEncryptionKey = nil
+		autoscalingConfig.Spec.ClusterAutoscaling = &gkev1.GKEClusterAutoscaling{
+			EnableNodeAutoprovisioning: true,
+			ResourceLimits: []gkev1.GKEResourceLimit{
+				{ResourceType: ResourceLimitCPU, Maximum: 32},
+				{ResourceType: ResourceLimitMemory, Maximum: 128},
+			},
+		}
+
+		createClusterRequest := NewClusterCreateRequest(autoscalingConfig)
+		Expect(createClusterRequest.Cluster.Autoscaling).To(Equal(NewClusterAutoscaling(autoscalingConfig.Spec.ClusterAutoscaling)))
+
+		clusterServiceMock.EXPECT().
+			ClusterCreate(
+				ctx,
+				LocationRRN(autoscalingConfig.Spec.ProjectID, Location(autoscalingConfig.Spec.Region, autoscalingConfig.Spec.Zone)),
+				createClusterRequest).
+			Return(&gkeapi.Operation{}, nil)
+
+		clusterServiceMock.EXPECT().
+			ClusterList(
+				ctx,
+				LocationRRN(autoscalingConfig.Spec.ProjectID, Location(autoscalingConfig.Spec.Region, autoscalingConfig.Spec.Zone))).
+			Return(&gkeapi.ListClustersResponse{}, nil)
+
+		err := Create(ctx, clusterServiceMock, autoscalingConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		autoscalingConfig.Spec.ClusterAutoscaling.ResourceLimits = nil
+		err = Create(ctx, clusterServiceMock, autoscalingConfig)
+		Expect(err).To(MatchError("node auto-provisioning requires cpu and memory resource limits for cluster [test-autoscaling-cluster (id: )]"))
+	})
+
+	It("should create cluster with addons", func() {
+		addonsConfig := config.DeepCopy()
+		addonsConfig.Spec.ClusterName = "test-addons-cluster"
+		addonsConfig.Spec.CustomerManagedEncryptionKey = nil
+		addonsConfig.Spec.ClusterAddons.GcePersistentDiskCsiDriver = &boolTrue
+		addonsConfig.Spec.ClusterAddons.DNSCacheConfig = &boolFalse
+		addonsConfig.Spec.ClusterAddons.VerticalPodAutoscaling = &boolTrue
+
+		createClusterRequest := NewClusterCreateRequest(addonsConfig)
+		Expect(createClusterRequest.Cluster.AddonsConfig).To(Equal(&gkeapi.AddonsConfig{
+			HttpLoadBalancing:        &gkeapi.HttpLoadBalancing{Disabled: false},
+			HorizontalPodAutoscaling: &gkeapi.HorizontalPodAutoscaling{Disabled: false},
+			NetworkPolicyConfig:      &gkeapi.NetworkPolicyConfig{Disabled: true},
+			GcePersistentDiskCsiDriverConfig: &gkeapi.GcePersistentDiskCsiDriverConfig{
+				Enabled:         true,
+				ForceSendFields: []string{"Enabled"},
+			},
+			DnsCacheConfig: &gkeapi.DnsCacheConfig{
+				Enabled:         false,
+				ForceSendFields: []string{"Enabled"},
+			},
+		}))
+		Expect(createClusterRequest.Cluster.VerticalPodAutoscaling).To(Equal(&gkeapi.VerticalPodAutoscaling{
+			Enabled:         true,
+			ForceSendFields: []string{"Enabled"},
+		}))
+
+		clusterServiceMock.EXPECT().
+			ClusterCreate(
+				ctx,
+				LocationRRN(addonsConfig.Spec.ProjectID, Location(addonsConfig.Spec.Region, addonsConfig.Spec.Zone)),
+				createClusterRequest).
+			Return(&gkeapi.Operation{}, nil)
+
+		clusterServiceMock.EXPECT().
+			ClusterList(
+				ctx,
+				LocationRRN(addonsConfig.Spec.ProjectID, Location(addonsConfig.Spec.Region, addonsConfig.Spec.Zone))).
+			Return(&gkeapi.ListClustersResponse{}, nil)
+
+		err := Create(ctx, clusterServiceMock, addonsConfig)
+		Expect(err).ToNot(HaveOccurred())
+
+		addonsConfig.Spec.ClusterAddons.ConfigConnectorConfig = &boolTrue
+		err = Create(ctx, clusterServiceMock, addonsConfig)
+		Expect(err).To(MatchError("clusterAddons.configConnectorConfig requires workload identity to be enabled for cluster [test-addons-cluster (id: )]"))
+	})
+
+	It("should create dual-stack Dataplane V2 cluster with Cloud DNS", func() {
+		dataplaneV2Config := config.DeepCopy()
+		dataplaneV2Config.Spec.ClusterName = "test-dataplane-v2-cluster"
+		dataplaneV2Config.Spec.CustomerManagedEncryptionKey = nil
+		dataplaneV2Config.Spec.NetworkPolicyEnabled = &boolFalse
+		datapathProvider := DatapathProviderAdvanced
+		dataplaneV2Config.Spec.DatapathProvider = &datapathProvider
+
+		dataplaneV2Config.Spec.DNSConfig = &gkev1.GKEDNSConfig{
+			ClusterDNS: ClusterDNSCloudDNS,
+		}
+		dataplaneV2Config.Spec.IPAllocationPolicy.StackType = StackTypeIPv4IPv6
+		dataplaneV2Config.Spec.IPAllocationPolicy.IPv6AccessType = IPv6AccessTypeInternal
+
+		createClusterRequest := NewClusterCreateRequest(dataplaneV2Config)
+		Expect(createClusterRequest.Cluster.NetworkConfig.DatapathProvider).To(Equal(DatapathProviderAdvanced))
+		Expect(createClusterRequest.Cluster.NetworkConfig.DnsConfig).To(Equal(&gkeapi.DNSConfig{ClusterDns: ClusterDNSCloudDNS}))
+		Expect(createClusterRequest.Cluster.IpAllocationPolicy.StackType).To(Equal(StackTypeIPv4IPv6))
+		Expect(createClusterRequest.Cluster.IpAllocationPolicy.Ipv6AccessType).To(Equal(IPv6AccessTypeInternal))
+
+		clusterServiceMock.EXPECT().
+			ClusterCreate(
+				ctx,
+				LocationRRN(dataplaneV2Config.Spec.ProjectID, Location(dataplaneV2Config.Spec.Region, dataplaneV2Config.Spec.Zone)),
+				createClusterRequest).
+			Return(&gkeapi.Operation{}, nil)
+
+		clusterServiceMock.EXPECT().
+			ClusterList(
+				ctx,
+				LocationRRN(dataplaneV2Config.Spec.ProjectID, Location(dataplaneV2Config.Spec.Region, dataplaneV2Config.Spec.Zone))).
+			Return(&gkeapi.ListClustersResponse{}, nil)
+
+		err := Create(ctx, clusterServiceMock, dataplaneV2Config)
+		Expect(err).ToNot(HaveOccurred())
+
+		dataplaneV2Config.Spec.NetworkPolicyEnabled = &boolTrue
+		err = Create(ctx, clusterServiceMock, dataplaneV2Config)
+		Expect(err).To(MatchError("field [networkPolicyEnabled] is not supported for Dataplane V2 cluster [test-dataplane-v2-cluster (id: )], which enforces network policies itself"))
+
+		dataplaneV2Config.Spec.NetworkPolicyEnabled = &boolFalse
+		dataplaneV2Config.Spec.ClusterAddons.NetworkPolicyConfig = true
+		err = Create(ctx, clusterServiceMock, dataplaneV2Config)
+		Expect(err).To(MatchError("field [clusterAddons.networkPolicyConfig] is not supported for Dataplane V2 cluster [test-dataplane-v2-cluster (id: )], which enforces network policies itself"))
+
+		dataplaneV2Config.Spec.ClusterAddons.NetworkPolicyConfig = false
+		legacyProvider := DatapathProviderLegacy
+		dataplaneV2Config.Spec.DatapathProvider = &legacyProvider
+		err = Create(ctx, clusterServiceMock, dataplaneV2Config)
+		Expect(err).To(MatchError("the IPV4_IPV6 stack type requires the ADVANCED_DATAPATH datapath provider for cluster [test-dataplane-v2-cluster (id: )]"))
+
+		invalidProvider := "EBPF"
+		dataplaneV2Config.Spec.DatapathProvider = &invalidProvider
+		err = Create(ctx, clusterServiceMock, dataplaneV2Config)
+		Expect(err).To(MatchError(`invalid datapath provider "EBPF" for cluster [test-dataplane-v2-cluster (id: )], must be LEGACY_DATAPATH or ADVANCED_DATAPATH`))
+	})
+
+	It("should create private cluster with master global access", func() {
+		privateConfig := config.DeepCopy()
+		privateConfig.Spec.CustomerManagedEncryptionKey = nil
+		privateConfig.Spec.PrivateClusterConfig = &gkev1.GKEPrivateClusterConfig{
+			EnablePrivateEndpoint:     true,
+			EnablePrivateNodes:        true,
+			PrivateEndpointSubnetwork: "control-plane-subnetwork",
+			MasterGlobalAccessConfig: &gkev1.GKEMasterGlobalAccessConfig{
+				Enabled: true,
+			},
+		}
+
+		createClusterRequest := NewClusterCreateRequest(privateConfig)
+		Expect(createClusterRequest.Cluster.PrivateClusterConfig).To(Equal(&gkeapi.PrivateClusterConfig{
+			EnablePrivateEndpoint:     true,
+			EnablePrivateNodes:        true,
+			PrivateEndpointSubnetwork: "control-plane-subnetwork",
+			MasterGlobalAccessConfig: &gkeapi.PrivateClusterMasterGlobalAccessConfig{
+				Enabled:         true,
+				ForceSendFields: []string{"Enabled"},
+			},
+		}))
+
+		clusterServiceMock.EXPECT().
+			ClusterList(
+				ctx,
+				LocationRRN(privateConfig.Spec.ProjectID, Location(privateConfig.Spec.Region, privateConfig.Spec.Zone))).
+			Return(&gkeapi.ListClustersResponse{}, nil).
+			Times(2)
+
+		privateConfig.Spec.PrivateClusterConfig.MasterIpv4CidrBlock = "172.16.0.0/28"
+		err := Create(ctx, clusterServiceMock, privateConfig)
+		Expect(err).To(MatchError("cannot specify both masterIpv4CidrBlock and privateEndpointSubnetwork for cluster [test-cluster (id: )]"))
+
+		privateConfig.Spec.PrivateClusterConfig = &gkev1.GKEPrivateClusterConfig{
+			MasterGlobalAccessConfig: &gkev1.GKEMasterGlobalAccessConfig{
+				Enabled: true,
+			},
+		}
+		err = Create(ctx, clusterServiceMock, privateConfig)
+		Expect(err).To(MatchError("master global access requires private nodes for cluster [test-cluster (id: )]"))
+	})
+
+	It("should fail to create cluster with an invalid release channel", func() {
+		channelConfig := config.DeepCopy()
+		channelConfig.Spec.CustomerManagedEncryptionKey = nil
+		releaseChannel := "NIGHTLY"
+		channelConfig.Spec.ReleaseChannel = &releaseChannel
+
+		err := Create(ctx, clusterServiceMock, channelConfig)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail to create cluster in a release channel with node auto-upgrade disabled", func() {
+		channelConfig := config.DeepCopy()
+		channelConfig.Spec.CustomerManagedEncryptionKey = nil
+		releaseChannel := ReleaseChannelStable
+		channelConfig.Spec.ReleaseChannel = &releaseChannel
+		channelConfig.Spec.NodePools = []gkev1.GKENodePoolConfig{
+			{
+				Name:              &nodePoolName,
+				InitialNodeCount:  &initialNodeCount,
+				Version:           &k8sVersion,
+				MaxPodsConstraint: &maxPodsConstraint,
+				Config:            &gkev1.GKENodeConfig{},
+				Management: &gkev1.GKENodePoolManagement{
+					AutoRepair:  true,
+					AutoUpgrade: false,
+				},
+			},
+		}
+
+		err := Create(ctx, clusterServiceMock, channelConfig)
+		Expect(err).To(MatchError("autoUpgrade must be enabled in the NodePool [test-node-pool] for cluster [test-cluster (id: )] enrolled in release channel STABLE"))
 	})
 
 	It("should fail create cluster with customer managment encryption key", func() {
@@ -202,12 +497,13 @@ var _ = Describe("CreateCluster", func() {
 	})
 
 	It("should fail to create autopilot cluster with nodepools", func() {
-		config.Spec.ClusterName = "test-autopilot-cluster"
-		config.Spec.AutopilotConfig = &gkev1.GKEAutopilotConfig{
+		autopilotConfig := config.DeepCopy()
+		autopilotConfig.Spec.ClusterName = "test-autopilot-cluster"
+		autopilotConfig.Spec.AutopilotConfig = &gkev1.GKEAutopilotConfig{
 			Enabled: true,
 		}
 
-		config.Spec.NodePools = []gkev1.GKENodePoolConfig{
+		autopilotConfig.Spec.NodePools = []gkev1.GKENodePoolConfig{
 			{
 				Name:              &nodePoolName,
 				InitialNodeCount:  &initialNodeCount,
@@ -226,8 +522,8 @@ var _ = Describe("CreateCluster", func() {
 			},
 		}
 
-		err := Create(ctx, clusterServiceMock, config)
-		Expect(err).To(HaveOccurred())
+		err := Create(ctx, clusterServiceMock, autopilotConfig)
+		Expect(err).To(MatchError("cannot create node pools for autopilot clusters"))
 	})
 
 	It("should fail to create cluster with duplicated nodepool names", func() {
@@ -382,4 +678,147 @@ var _ = Describe("CreateNodePool", func() {
 		Expect(err).To(HaveOccurred())
 		Expect(status).To(Equal(NotChanged))
 	})
+
+	It("should create node pool with upgrade settings", func() {
+		surgeNodePoolConfig := nodePoolConfig.DeepCopy()
+		surgeNodePoolConfig.UpgradeSettings = &gkev1.GKENodePoolUpgradeSettings{
+			MaxSurge:       0,
+			MaxUnavailable: 1,
+		}
+
+		createNodePoolRequest, err := newNodePoolCreateRequest(surgeNodePoolConfig, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createNodePoolRequest.NodePool.UpgradeSettings).To(Equal(&gkeapi.UpgradeSettings{
+			Strategy:        UpgradeStrategySurge,
+			MaxSurge:        0,
+			MaxUnavailable:  1,
+			ForceSendFields: []string{"MaxSurge", "MaxUnavailable"},
+		}))
+		clusterServiceMock.EXPECT().
+			NodePoolCreate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				createNodePoolRequest).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := CreateNodePool(ctx, clusterServiceMock, config, surgeNodePoolConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("shouldn't create node pool with invalid upgrade settings", func() {
+		invalidNodePoolConfig := nodePoolConfig.DeepCopy()
+		invalidNodePoolConfig.UpgradeSettings = &gkev1.GKENodePoolUpgradeSettings{
+			Strategy: UpgradeStrategySurge,
+		}
+		status, err := CreateNodePool(ctx, clusterServiceMock, config, invalidNodePoolConfig)
+		Expect(err).To(MatchError("maxSurge and maxUnavailable cannot both be 0 for node pool [test-node-pool] in cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should create node pool with normalized taint effects", func() {
+		taintedNodePoolConfig := nodePoolConfig.DeepCopy()
+		taintedNodePoolConfig.Config.Taints = []gkev1.GKENodeTaintConfig{
+			{Effect: "NoSchedule", Key: "group", Value: "examples"},
+			{Effect: TaintEffectNoExecute, Key: "dedicated", Value: "db"},
+		}
+		taintedNodePoolConfig.Config.ResourceLabels = map[string]string{"team": "data"}
+
+		createNodePoolRequest, err := newNodePoolCreateRequest(taintedNodePoolConfig, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createNodePoolRequest.NodePool.Config.Taints).To(Equal([]*gkeapi.NodeTaint{
+			{Effect: TaintEffectNoSchedule, Key: "group", Value: "examples"},
+			{Effect: TaintEffectNoExecute, Key: "dedicated", Value: "db"},
+		}))
+		Expect(createNodePoolRequest.NodePool.Config.ResourceLabels).To(Equal(map[string]string{"team": "data"}))
+
+		taintedNodePoolConfig.Config.Taints[0].Effect = "NoWay"
+		status, err := CreateNodePool(ctx, clusterServiceMock, config, taintedNodePoolConfig)
+		Expect(err).To(MatchError(`invalid effect "NoWay" for taint [group] in node pool [test-node-pool] of cluster [test-cluster (id: )]`))
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should create node pool in the given locations", func() {
+		zonalNodePoolConfig := nodePoolConfig.DeepCopy()
+		zonalNodePoolConfig.Locations = []string{"test-region-a"}
+
+		createNodePoolRequest, err := newNodePoolCreateRequest(zonalNodePoolConfig, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createNodePoolRequest.NodePool.Locations).To(Equal([]string{"test-region-a"}))
+	})
+
+	It("should create node pool with its own pod range", func() {
+		podRangeNodePoolConfig := nodePoolConfig.DeepCopy()
+		podRangeNodePoolConfig.NetworkConfig = &gkev1.GKENodeNetworkConfig{
+			PodRange:         "pods-2",
+			PodIpv4CidrBlock: "10.8.0.0/14",
+			CreatePodRange:   true,
+		}
+
+		createNodePoolRequest, err := newNodePoolCreateRequest(podRangeNodePoolConfig, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createNodePoolRequest.NodePool.NetworkConfig).To(Equal(&gkeapi.NodeNetworkConfig{
+			PodRange:         "pods-2",
+			PodIpv4CidrBlock: "10.8.0.0/14",
+			CreatePodRange:   true,
+		}))
+
+		podRangeNodePoolConfig.NetworkConfig = &gkev1.GKENodeNetworkConfig{PodIpv4CidrBlock: "10.8.0.0/14"}
+		status, err := CreateNodePool(ctx, clusterServiceMock, config, podRangeNodePoolConfig)
+		Expect(err).To(MatchError("podRange must be set unless createPodRange is set for node pool [test-node-pool] in cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should create node pool with spot VMs", func() {
+		spotNodePoolConfig := nodePoolConfig.DeepCopy()
+		spotNodePoolConfig.Config.Spot = true
+
+		createNodePoolRequest, err := newNodePoolCreateRequest(spotNodePoolConfig, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createNodePoolRequest.NodePool.Config.Spot).To(BeTrue())
+		Expect(createNodePoolRequest.NodePool.Config.Preemptible).To(BeFalse())
+
+		spotNodePoolConfig.Config.Preemptible = true
+		status, err := CreateNodePool(ctx, clusterServiceMock, config, spotNodePoolConfig)
+		Expect(err).To(MatchError("only one of preemptible or spot can be set for node pool [test-node-pool] in cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should create node pool with accelerators", func() {
+		gpuNodePoolConfig := nodePoolConfig.DeepCopy()
+		gpuNodePoolConfig.Config.Accelerators = []gkev1.GKEAcceleratorConfig{
+			{AcceleratorCount: 2, AcceleratorType: "nvidia-tesla-t4"},
+		}
+
+		createNodePoolRequest, err := newNodePoolCreateRequest(gpuNodePoolConfig, config)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(createNodePoolRequest.NodePool.Config.Accelerators).To(Equal([]*gkeapi.AcceleratorConfig{
+			{AcceleratorCount: 2, AcceleratorType: "nvidia-tesla-t4"},
+		}))
+		clusterServiceMock.EXPECT().
+			NodePoolCreate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				createNodePoolRequest).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := CreateNodePool(ctx, clusterServiceMock, config, gpuNodePoolConfig)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+
+		gpuNodePoolConfig.Config.Accelerators[0].AcceleratorCount = 0
+		status, err = CreateNodePool(ctx, clusterServiceMock, config, gpuNodePoolConfig)
+		Expect(err).To(MatchError("acceleratorCount must be > 0 for accelerator [nvidia-tesla-t4] of node pool [test-node-pool] in cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("shouldn't create node pool for autopilot cluster", func() {
+		autopilotConfig := config.DeepCopy()
+		autopilotConfig.Spec.AutopilotConfig = &gkev1.GKEAutopilotConfig{
+			Enabled: true,
+		}
+		status, err := CreateNodePool(ctx, clusterServiceMock, autopilotConfig, nodePoolConfig)
+		Expect(err).To(MatchError("cannot create node pool [test-node-pool] for autopilot cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
 })
@@ -0,0 +1,65 @@
+package gke
+
+import (
+	"fmt"
+	"strings"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+)
+
+// Database Encryption States
+const (
+	// DatabaseEncryptionEncrypted encrypts the secrets of the cluster with a Cloud KMS key
+	DatabaseEncryptionEncrypted = "ENCRYPTED"
+	// DatabaseEncryptionDecrypted leaves the secrets of the cluster encrypted by GKE only
+	DatabaseEncryptionDecrypted = "DECRYPTED"
+)
+
+// BuildDatabaseEncryption returns the spec representation of the given GKE database encryption.
+func BuildDatabaseEncryption(databaseEncryption *gkeapi.DatabaseEncryption) *gkev1.GKEDatabaseEncryption {
+	if databaseEncryption == nil {
+		return &gkev1.GKEDatabaseEncryption{
+			State: DatabaseEncryptionDecrypted,
+		}
+	}
+	return &gkev1.GKEDatabaseEncryption{
+		State:        databaseEncryption.State,
+		KeyName:      databaseEncryption.KeyName,
+		CurrentState: databaseEncryption.CurrentState,
+	}
+}
+
+// databaseEncryptionPending returns true while GKE is encrypting or decrypting the secrets of the
+// cluster, which can take a long time after the update operation itself is done.
+func databaseEncryptionPending(databaseEncryption *gkev1.GKEDatabaseEncryption) bool {
+	return databaseEncryption != nil && strings.HasSuffix(databaseEncryption.CurrentState, "_PENDING")
+}
+
+// databaseEncryptionEqual returns true if the upstream database encryption matches the spec. The
+// key is only compared when the secrets are encrypted.
+func databaseEncryptionEqual(databaseEncryption, upstream *gkev1.GKEDatabaseEncryption) bool {
+	if databaseEncryption.State != upstream.State {
+		return false
+	}
+	return databaseEncryption.State != DatabaseEncryptionEncrypted || databaseEncryption.KeyName == upstream.KeyName
+}
+
+func validateDatabaseEncryption(config *gkev1.GKEClusterConfig) error {
+	databaseEncryption := config.Spec.DatabaseEncryption
+	if databaseEncryption == nil {
+		return nil
+	}
+	clusterName := config.Spec.ClusterName
+	switch databaseEncryption.State {
+	case DatabaseEncryptionEncrypted:
+		if databaseEncryption.KeyName == "" {
+			return fmt.Errorf("keyName is required to encrypt the database of cluster [%s (id: %s)]", clusterName, config.Name)
+		}
+	case "", DatabaseEncryptionDecrypted:
+	default:
+		return fmt.Errorf("invalid database encryption state %q for cluster [%s (id: %s)], must be %s or %s", databaseEncryption.State, clusterName, config.Name, DatabaseEncryptionEncrypted, DatabaseEncryptionDecrypted)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package gke
+
+import (
+	"fmt"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+)
+
+// Cluster DNS Providers
+const (
+	// ClusterDNSPlatformDefault uses the default DNS provider of the platform, kube-dns
+	ClusterDNSPlatformDefault = "PLATFORM_DEFAULT"
+	// ClusterDNSCloudDNS uses Cloud DNS for cluster DNS
+	ClusterDNSCloudDNS = "CLOUD_DNS"
+	// ClusterDNSKubeDNS uses kube-dns running in the cluster
+	ClusterDNSKubeDNS = "KUBE_DNS"
+)
+
+// Cluster DNS Scopes
+const (
+	// ClusterDNSScopeCluster makes the DNS records resolvable only within the cluster
+	ClusterDNSScopeCluster = "CLUSTER_SCOPE"
+	// ClusterDNSScopeVPC makes the DNS records resolvable within the VPC of the cluster
+	ClusterDNSScopeVPC = "VPC_SCOPE"
+)
+
+// NewDNSConfig returns the GKE DNS configuration for the given spec.
+func NewDNSConfig(dnsConfig *gkev1.GKEDNSConfig) *gkeapi.DNSConfig {
+	return &gkeapi.DNSConfig{
+		ClusterDns:       dnsConfig.ClusterDNS,
+		ClusterDnsScope:  dnsConfig.ClusterDNSScope,
+		ClusterDnsDomain: dnsConfig.ClusterDNSDomain,
+	}
+}
+
+// BuildDNSConfig returns the spec representation of the given GKE DNS configuration.
+func BuildDNSConfig(dnsConfig *gkeapi.DNSConfig) *gkev1.GKEDNSConfig {
+	if dnsConfig == nil {
+		return &gkev1.GKEDNSConfig{}
+	}
+	return &gkev1.GKEDNSConfig{
+		ClusterDNS:       dnsConfig.ClusterDns,
+		ClusterDNSScope:  dnsConfig.ClusterDnsScope,
+		ClusterDNSDomain: dnsConfig.ClusterDnsDomain,
+	}
+}
+
+// dnsConfigEqual returns true if the upstream DNS configuration matches the spec. Fields left
+// empty in the spec are defaulted by GKE, so they are not compared.
+func dnsConfigEqual(dnsConfig, upstream *gkev1.GKEDNSConfig) bool {
+	if upstream == nil {
+		upstream = &gkev1.GKEDNSConfig{}
+	}
+	switch {
+	case dnsConfig.ClusterDNS != "" && dnsConfig.ClusterDNS != upstream.ClusterDNS:
+		return false
+	case dnsConfig.ClusterDNSScope != "" && dnsConfig.ClusterDNSScope != upstream.ClusterDNSScope:
+		return false
+	case dnsConfig.ClusterDNSDomain != "" && dnsConfig.ClusterDNSDomain != upstream.ClusterDNSDomain:
+		return false
+	}
+	return true
+}
+
+func validateDNSConfig(config *gkev1.GKEClusterConfig) error {
+	dnsConfig := config.Spec.DNSConfig
+	if dnsConfig == nil {
+		return nil
+	}
+	clusterName := config.Spec.ClusterName
+	switch dnsConfig.ClusterDNS {
+	case "", ClusterDNSPlatformDefault, ClusterDNSCloudDNS, ClusterDNSKubeDNS:
+	default:
+		return fmt.Errorf("invalid cluster DNS provider %q for cluster [%s (id: %s)], must be %s, %s or %s", dnsConfig.ClusterDNS, clusterName, config.Name, ClusterDNSPlatformDefault, ClusterDNSCloudDNS, ClusterDNSKubeDNS)
+	}
+	switch dnsConfig.ClusterDNSScope {
+	case "":
+	case ClusterDNSScopeCluster, ClusterDNSScopeVPC:
+		if dnsConfig.ClusterDNS != ClusterDNSCloudDNS {
+			return fmt.Errorf("clusterDnsScope requires the %s provider for cluster [%s (id: %s)]", ClusterDNSCloudDNS, clusterName, config.Name)
+		}
+	default:
+		return fmt.Errorf("invalid cluster DNS scope %q for cluster [%s (id: %s)], must be %s or %s", dnsConfig.ClusterDNSScope, clusterName, config.Name, ClusterDNSScopeCluster, ClusterDNSScopeVPC)
+	}
+	if (dnsConfig.ClusterDNSScope == ClusterDNSScopeVPC) != (dnsConfig.ClusterDNSDomain != "") {
+		return fmt.Errorf("clusterDnsDomain must be set if and only if clusterDnsScope is %s for cluster [%s (id: %s)]", ClusterDNSScopeVPC, clusterName, config.Name)
+	}
+	return nil
+}
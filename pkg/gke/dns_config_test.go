@@ -0,0 +1,68 @@
+package gke
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	gkeapi "google.golang.org/api/container/v1"
+)
+
+var _ = Describe("DNSConfig", func() {
+	var config *gkev1.GKEClusterConfig
+
+	BeforeEach(func() {
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName: "test-cluster",
+				DNSConfig: &gkev1.GKEDNSConfig{
+					ClusterDNS:       ClusterDNSCloudDNS,
+					ClusterDNSScope:  ClusterDNSScopeVPC,
+					ClusterDNSDomain: "test.example",
+				},
+			},
+		}
+	})
+
+	It("should round-trip the DNS config", func() {
+		dnsConfig := NewDNSConfig(config.Spec.DNSConfig)
+		Expect(dnsConfig).To(Equal(&gkeapi.DNSConfig{
+			ClusterDns:       ClusterDNSCloudDNS,
+			ClusterDnsScope:  ClusterDNSScopeVPC,
+			ClusterDnsDomain: "test.example",
+		}))
+		Expect(BuildDNSConfig(dnsConfig)).To(Equal(config.Spec.DNSConfig))
+		Expect(BuildDNSConfig(nil)).To(Equal(&gkev1.GKEDNSConfig{}))
+	})
+
+	It("should compare the DNS config", func() {
+		Expect(dnsConfigEqual(config.Spec.DNSConfig, config.Spec.DNSConfig)).To(BeTrue())
+		Expect(dnsConfigEqual(config.Spec.DNSConfig, nil)).To(BeFalse())
+		Expect(dnsConfigEqual(&gkev1.GKEDNSConfig{ClusterDNS: ClusterDNSCloudDNS}, config.Spec.DNSConfig)).To(BeTrue())
+		Expect(dnsConfigEqual(&gkev1.GKEDNSConfig{ClusterDNS: ClusterDNSKubeDNS}, config.Spec.DNSConfig)).To(BeFalse())
+	})
+
+	It("should validate the DNS config", func() {
+		Expect(validateDNSConfig(config)).To(Succeed())
+
+		invalidConfig := config.DeepCopy()
+		invalidConfig.Spec.DNSConfig.ClusterDNS = "CORE_DNS"
+		Expect(validateDNSConfig(invalidConfig)).To(MatchError(`invalid cluster DNS provider "CORE_DNS" for cluster [test-cluster (id: )], must be PLATFORM_DEFAULT, CLOUD_DNS or KUBE_DNS`))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.DNSConfig.ClusterDNS = ClusterDNSKubeDNS
+		Expect(validateDNSConfig(invalidConfig)).To(MatchError("clusterDnsScope requires the CLOUD_DNS provider for cluster [test-cluster (id: )]"))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.DNSConfig.ClusterDNSScope = "GLOBAL_SCOPE"
+		Expect(validateDNSConfig(invalidConfig)).To(HaveOccurred())
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.DNSConfig.ClusterDNSDomain = ""
+		Expect(validateDNSConfig(invalidConfig)).To(MatchError("clusterDnsDomain must be set if and only if clusterDnsScope is VPC_SCOPE for cluster [test-cluster (id: )]"))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.DNSConfig.ClusterDNSScope = ClusterDNSScopeCluster
+		Expect(validateDNSConfig(invalidConfig)).To(HaveOccurred())
+	})
+})
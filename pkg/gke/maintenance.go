@@ -0,0 +1,170 @@
+package gke
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+)
+
+// Maintenance exclusion scopes
+const (
+	// MaintenanceExclusionNoUpgrades excludes all upgrades. This is the default scope.
+	MaintenanceExclusionNoUpgrades = "NO_UPGRADES"
+	// MaintenanceExclusionNoMinorUpgrades excludes minor upgrades, patch upgrades are still allowed.
+	MaintenanceExclusionNoMinorUpgrades = "NO_MINOR_UPGRADES"
+	// MaintenanceExclusionNoMinorOrNodeUpgrades excludes minor upgrades and node pool upgrades.
+	MaintenanceExclusionNoMinorOrNodeUpgrades = "NO_MINOR_OR_NODE_UPGRADES"
+)
+
+// NewMaintenancePolicy returns the GKE maintenance policy for the given spec. The resource
+// version is left empty, it must be set from the upstream policy when updating it.
+func NewMaintenancePolicy(policy *gkev1.GKEMaintenancePolicy) *gkeapi.MaintenancePolicy {
+	window := &gkeapi.MaintenanceWindow{}
+	if policy.DailyMaintenanceWindow != nil {
+		window.DailyMaintenanceWindow = &gkeapi.DailyMaintenanceWindow{
+			StartTime: policy.DailyMaintenanceWindow.StartTime,
+		}
+	}
+	if policy.RecurringWindow != nil {
+		window.RecurringWindow = &gkeapi.RecurringTimeWindow{
+			Recurrence: policy.RecurringWindow.Recurrence,
+			Window: &gkeapi.TimeWindow{
+				StartTime: policy.RecurringWindow.StartTime,
+				EndTime:   policy.RecurringWindow.EndTime,
+			},
+		}
+	}
+	if len(policy.MaintenanceExclusions) != 0 {
+		window.MaintenanceExclusions = make(map[string]gkeapi.TimeWindow, len(policy.MaintenanceExclusions))
+		for _, exclusion := range policy.MaintenanceExclusions {
+			timeWindow := gkeapi.TimeWindow{
+				StartTime: exclusion.StartTime,
+				EndTime:   exclusion.EndTime,
+			}
+			if exclusion.Scope != "" {
+				timeWindow.MaintenanceExclusionOptions = &gkeapi.MaintenanceExclusionOptions{
+					Scope: exclusion.Scope,
+				}
+			}
+			window.MaintenanceExclusions[exclusion.Name] = timeWindow
+		}
+	}
+	if reflect.DeepEqual(window, &gkeapi.MaintenanceWindow{}) {
+		// an empty policy removes all maintenance windows and exclusions
+		return &gkeapi.MaintenancePolicy{}
+	}
+	return &gkeapi.MaintenancePolicy{
+		Window: window,
+	}
+}
+
+// BuildMaintenancePolicy returns the spec representation of the given GKE maintenance policy,
+// including its resource version. Exclusions are sorted by name.
+func BuildMaintenancePolicy(policy *gkeapi.MaintenancePolicy) *gkev1.GKEMaintenancePolicy {
+	maintenancePolicy := &gkev1.GKEMaintenancePolicy{}
+	if policy == nil {
+		return maintenancePolicy
+	}
+	maintenancePolicy.ResourceVersion = policy.ResourceVersion
+	if policy.Window == nil {
+		return maintenancePolicy
+	}
+
+	if policy.Window.DailyMaintenanceWindow != nil {
+		maintenancePolicy.DailyMaintenanceWindow = &gkev1.GKEDailyMaintenanceWindow{
+			StartTime: policy.Window.DailyMaintenanceWindow.StartTime,
+		}
+	}
+	if recurring := policy.Window.RecurringWindow; recurring != nil {
+		maintenancePolicy.RecurringWindow = &gkev1.GKERecurringTimeWindow{
+			Recurrence: recurring.Recurrence,
+		}
+		if recurring.Window != nil {
+			maintenancePolicy.RecurringWindow.StartTime = recurring.Window.StartTime
+			maintenancePolicy.RecurringWindow.EndTime = recurring.Window.EndTime
+		}
+	}
+	for name, timeWindow := range policy.Window.MaintenanceExclusions {
+		exclusion := gkev1.GKEMaintenanceExclusion{
+			Name:      name,
+			StartTime: timeWindow.StartTime,
+			EndTime:   timeWindow.EndTime,
+		}
+		if timeWindow.MaintenanceExclusionOptions != nil {
+			exclusion.Scope = timeWindow.MaintenanceExclusionOptions.Scope
+		}
+		maintenancePolicy.MaintenanceExclusions = append(maintenancePolicy.MaintenanceExclusions, exclusion)
+	}
+	sort.Slice(maintenancePolicy.MaintenanceExclusions, func(i, j int) bool {
+		return maintenancePolicy.MaintenanceExclusions[i].Name < maintenancePolicy.MaintenanceExclusions[j].Name
+	})
+	return maintenancePolicy
+}
+
+// maintenancePoliciesEqual compares two maintenance policies, ignoring the resource version,
+// the order of the exclusions and the default exclusion scope.
+func maintenancePoliciesEqual(lh, rh *gkev1.GKEMaintenancePolicy) bool {
+	return reflect.DeepEqual(normalizeMaintenancePolicy(lh), normalizeMaintenancePolicy(rh))
+}
+
+func normalizeMaintenancePolicy(policy *gkev1.GKEMaintenancePolicy) *gkev1.GKEMaintenancePolicy {
+	if policy == nil {
+		return &gkev1.GKEMaintenancePolicy{}
+	}
+	policy = policy.DeepCopy()
+	policy.ResourceVersion = ""
+	if len(policy.MaintenanceExclusions) == 0 {
+		policy.MaintenanceExclusions = nil
+	}
+	for i := range policy.MaintenanceExclusions {
+		if policy.MaintenanceExclusions[i].Scope == "" {
+			policy.MaintenanceExclusions[i].Scope = MaintenanceExclusionNoUpgrades
+		}
+	}
+	sort.Slice(policy.MaintenanceExclusions, func(i, j int) bool {
+		return policy.MaintenanceExclusions[i].Name < policy.MaintenanceExclusions[j].Name
+	})
+	return policy
+}
+
+func validateMaintenancePolicy(config *gkev1.GKEClusterConfig) error {
+	policy := config.Spec.MaintenancePolicy
+	if policy == nil {
+		return nil
+	}
+	clusterName := config.Spec.ClusterName
+	if config.Spec.MaintenanceWindow != nil && *config.Spec.MaintenanceWindow != "" {
+		return fmt.Errorf("only one of maintenanceWindow or maintenancePolicy can be set for cluster [%s (id: %s)]", clusterName, config.Name)
+	}
+	if policy.DailyMaintenanceWindow != nil && policy.RecurringWindow != nil {
+		return fmt.Errorf("only one of dailyMaintenanceWindow or recurringWindow can be set in the maintenance policy for cluster [%s (id: %s)]", clusterName, config.Name)
+	}
+	if policy.DailyMaintenanceWindow != nil && policy.DailyMaintenanceWindow.StartTime == "" {
+		return fmt.Errorf("startTime is required for the daily maintenance window of cluster [%s (id: %s)]", clusterName, config.Name)
+	}
+	if recurring := policy.RecurringWindow; recurring != nil {
+		if recurring.StartTime == "" || recurring.EndTime == "" || recurring.Recurrence == "" {
+			return fmt.Errorf("startTime, endTime and recurrence are required for the recurring maintenance window of cluster [%s (id: %s)]", clusterName, config.Name)
+		}
+	}
+	exclusions := map[string]bool{}
+	for _, exclusion := range policy.MaintenanceExclusions {
+		if exclusion.Name == "" || exclusion.StartTime == "" || exclusion.EndTime == "" {
+			return fmt.Errorf("name, startTime and endTime are required for maintenance exclusions of cluster [%s (id: %s)]", clusterName, config.Name)
+		}
+		if exclusions[exclusion.Name] {
+			return fmt.Errorf("maintenance exclusion name [%s] is not unique within the cluster [%s (id: %s)]", exclusion.Name, clusterName, config.Name)
+		}
+		exclusions[exclusion.Name] = true
+		switch exclusion.Scope {
+		case "", MaintenanceExclusionNoUpgrades, MaintenanceExclusionNoMinorUpgrades, MaintenanceExclusionNoMinorOrNodeUpgrades:
+		default:
+			return fmt.Errorf("invalid scope %q for maintenance exclusion [%s] of cluster [%s (id: %s)]", exclusion.Scope, exclusion.Name, clusterName, config.Name)
+		}
+	}
+	return nil
+}
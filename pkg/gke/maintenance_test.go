@@ -0,0 +1,123 @@
+package gke
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	gkeapi "google.golang.org/api/container/v1"
+)
+
+var _ = Describe("MaintenancePolicy", func() {
+	var (
+		emptyString = ""
+		config      *gkev1.GKEClusterConfig
+	)
+
+	BeforeEach(func() {
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName:       "test-cluster",
+				MaintenanceWindow: &emptyString,
+				MaintenancePolicy: &gkev1.GKEMaintenancePolicy{
+					RecurringWindow: &gkev1.GKERecurringTimeWindow{
+						StartTime:  "2024-01-06T02:00:00Z",
+						EndTime:    "2024-01-06T08:00:00Z",
+						Recurrence: "FREQ=WEEKLY;BYDAY=SA,SU",
+					},
+					MaintenanceExclusions: []gkev1.GKEMaintenanceExclusion{
+						{
+							Name:      "holiday-freeze",
+							StartTime: "2024-12-20T00:00:00Z",
+							EndTime:   "2025-01-05T00:00:00Z",
+							Scope:     MaintenanceExclusionNoMinorUpgrades,
+						},
+						{
+							Name:      "black-friday",
+							StartTime: "2024-11-29T00:00:00Z",
+							EndTime:   "2024-11-30T00:00:00Z",
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("should build the GKE maintenance policy", func() {
+		policy := NewMaintenancePolicy(config.Spec.MaintenancePolicy)
+		Expect(policy).To(Equal(&gkeapi.MaintenancePolicy{
+			Window: &gkeapi.MaintenanceWindow{
+				RecurringWindow: &gkeapi.RecurringTimeWindow{
+					Recurrence: "FREQ=WEEKLY;BYDAY=SA,SU",
+					Window: &gkeapi.TimeWindow{
+						StartTime: "2024-01-06T02:00:00Z",
+						EndTime:   "2024-01-06T08:00:00Z",
+					},
+				},
+				MaintenanceExclusions: map[string]gkeapi.TimeWindow{
+					"holiday-freeze": {
+						StartTime: "2024-12-20T00:00:00Z",
+						EndTime:   "2025-01-05T00:00:00Z",
+						MaintenanceExclusionOptions: &gkeapi.MaintenanceExclusionOptions{
+							Scope: MaintenanceExclusionNoMinorUpgrades,
+						},
+					},
+					"black-friday": {
+						StartTime: "2024-11-29T00:00:00Z",
+						EndTime:   "2024-11-30T00:00:00Z",
+					},
+				},
+			},
+		}))
+
+		Expect(NewMaintenancePolicy(&gkev1.GKEMaintenancePolicy{})).To(Equal(&gkeapi.MaintenancePolicy{}))
+	})
+
+	It("should round-trip the maintenance policy", func() {
+		policy := NewMaintenancePolicy(config.Spec.MaintenancePolicy)
+		policy.ResourceVersion = "abc123"
+
+		maintenancePolicy := BuildMaintenancePolicy(policy)
+		Expect(maintenancePolicy.ResourceVersion).To(Equal("abc123"))
+		Expect(maintenancePolicy.RecurringWindow).To(Equal(config.Spec.MaintenancePolicy.RecurringWindow))
+		Expect(maintenancePolicy.MaintenanceExclusions).To(HaveLen(2))
+		Expect(maintenancePolicy.MaintenanceExclusions[0].Name).To(Equal("black-friday"))
+		Expect(maintenancePoliciesEqual(config.Spec.MaintenancePolicy, maintenancePolicy)).To(BeTrue())
+
+		Expect(BuildMaintenancePolicy(nil)).To(Equal(&gkev1.GKEMaintenancePolicy{}))
+	})
+
+	It("should treat an empty exclusion scope as no upgrades", func() {
+		upstream := config.Spec.MaintenancePolicy.DeepCopy()
+		upstream.MaintenanceExclusions[1].Scope = MaintenanceExclusionNoUpgrades
+		Expect(maintenancePoliciesEqual(config.Spec.MaintenancePolicy, upstream)).To(BeTrue())
+
+		upstream.MaintenanceExclusions[1].Scope = MaintenanceExclusionNoMinorOrNodeUpgrades
+		Expect(maintenancePoliciesEqual(config.Spec.MaintenancePolicy, upstream)).To(BeFalse())
+	})
+
+	It("should validate the maintenance policy", func() {
+		Expect(validateMaintenancePolicy(config)).To(Succeed())
+
+		invalidConfig := config.DeepCopy()
+		window := "03:00"
+		invalidConfig.Spec.MaintenanceWindow = &window
+		Expect(validateMaintenancePolicy(invalidConfig)).To(MatchError("only one of maintenanceWindow or maintenancePolicy can be set for cluster [test-cluster (id: )]"))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.MaintenancePolicy.DailyMaintenanceWindow = &gkev1.GKEDailyMaintenanceWindow{StartTime: "03:00"}
+		Expect(validateMaintenancePolicy(invalidConfig)).To(HaveOccurred())
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.MaintenancePolicy.RecurringWindow.Recurrence = ""
+		Expect(validateMaintenancePolicy(invalidConfig)).To(HaveOccurred())
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.MaintenancePolicy.MaintenanceExclusions[1].Name = "holiday-freeze"
+		Expect(validateMaintenancePolicy(invalidConfig)).To(MatchError("maintenance exclusion name [holiday-freeze] is not unique within the cluster [test-cluster (id: )]"))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.MaintenancePolicy.MaintenanceExclusions[0].Scope = "NO_PATCHES"
+		Expect(validateMaintenancePolicy(invalidConfig)).To(HaveOccurred())
+	})
+})
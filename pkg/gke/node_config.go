@@ -0,0 +1,261 @@
+package gke
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/utils"
+)
+
+// Taint Effects
+const (
+	// TaintEffectNoSchedule is the GKE style NoSchedule taint effect
+	TaintEffectNoSchedule = "NO_SCHEDULE"
+	// TaintEffectPreferNoSchedule is the GKE style PreferNoSchedule taint effect
+	TaintEffectPreferNoSchedule = "PREFER_NO_SCHEDULE"
+	// TaintEffectNoExecute is the GKE style NoExecute taint effect
+	TaintEffectNoExecute = "NO_EXECUTE"
+)
+
+// NodePoolReplacementPolicyReplace replaces a node pool when its configuration cannot be updated in place
+const NodePoolReplacementPolicyReplace = "Replace"
+
+var kubernetesTaintEffects = map[string]string{
+	"NoSchedule":       TaintEffectNoSchedule,
+	"PreferNoSchedule": TaintEffectPreferNoSchedule,
+	"NoExecute":        TaintEffectNoExecute,
+}
+
+// NormalizeTaintEffect returns the GKE style of a Kubernetes style taint effect such as NoSchedule.
+// Effects already in the GKE style are returned as is.
+func NormalizeTaintEffect(effect string) string {
+	if gkeEffect, ok := kubernetesTaintEffects[effect]; ok {
+		return gkeEffect
+	}
+	return effect
+}
+
+func isValidTaintEffect(effect string) bool {
+	switch NormalizeTaintEffect(effect) {
+	case TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute:
+		return true
+	}
+	return false
+}
+
+func newNodeTaints(taints []gkev1.GKENodeTaintConfig) []*gkeapi.NodeTaint {
+	nodeTaints := make([]*gkeapi.NodeTaint, 0, len(taints))
+	for _, t := range taints {
+		nodeTaints = append(nodeTaints, &gkeapi.NodeTaint{
+			Effect: NormalizeTaintEffect(t.Effect),
+			Key:    t.Key,
+			Value:  t.Value,
+		})
+	}
+	return nodeTaints
+}
+
+// taintsEqual compares two lists of taints, ignoring their order and the style of their effects.
+func taintsEqual(lh, rh []gkev1.GKENodeTaintConfig) bool {
+	return reflect.DeepEqual(normalizeTaints(lh), normalizeTaints(rh))
+}
+
+func normalizeTaints(taints []gkev1.GKENodeTaintConfig) []gkev1.GKENodeTaintConfig {
+	normalized := make([]gkev1.GKENodeTaintConfig, 0, len(taints))
+	for _, t := range taints {
+		t.Effect = NormalizeTaintEffect(t.Effect)
+		normalized = append(normalized, t)
+	}
+	sort.Slice(normalized, func(i, j int) bool {
+		if normalized[i].Key != normalized[j].Key {
+			return normalized[i].Key < normalized[j].Key
+		}
+		if normalized[i].Effect != normalized[j].Effect {
+			return normalized[i].Effect < normalized[j].Effect
+		}
+		return normalized[i].Value < normalized[j].Value
+	})
+	return normalized
+}
+
+// stringSetsEqual compares two lists of strings, ignoring their order.
+func stringSetsEqual(lh, rh []string) bool {
+	if len(lh) != len(rh) {
+		return false
+	}
+	lh = append([]string{}, lh...)
+	rh = append([]string{}, rh...)
+	sort.Strings(lh)
+	sort.Strings(rh)
+	return reflect.DeepEqual(lh, rh)
+}
+
+// labelsEqual compares two maps of labels, treating nil and empty maps as equal.
+func labelsEqual(lh, rh map[string]string) bool {
+	if len(lh) == 0 && len(rh) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(lh, rh)
+}
+
+// newNodePoolConfigUpdateRequest returns a request updating the first of the node labels, taints,
+// network tags, resource labels, private nodes override and workload metadata mode that differ from
+// upstream, or nil if they are all up to date. Fields that are nil in the spec are not managed,
+// except for the workload metadata mode which follows the workload identity of the cluster when it
+// is not set. Only one of them is updated at a time, the remaining ones are updated on the next
+// reconcile loop.
+func newNodePoolConfigUpdateRequest(nodePool, upstreamNodePool *gkev1.GKENodePoolConfig, clusterConfig *gkev1.GKEClusterConfig) (*gkeapi.UpdateNodePoolRequest, string) {
+	config := nodePool.Config
+	if config == nil {
+		config = &gkev1.GKENodeConfig{}
+	}
+	upstreamConfig := upstreamNodePool.Config
+	if upstreamConfig == nil {
+		upstreamConfig = &gkev1.GKENodeConfig{}
+	}
+	network := nodePool.NetworkConfig
+	if network == nil {
+		network = &gkev1.GKENodeNetworkConfig{}
+	}
+	upstreamNetwork := upstreamNodePool.NetworkConfig
+	if upstreamNetwork == nil {
+		upstreamNetwork = &gkev1.GKENodeNetworkConfig{}
+	}
+	workloadMetadata := desiredWorkloadMetadataMode(nodePool, upstreamNodePool, clusterConfig)
+
+	switch {
+	case config.Labels != nil && !labelsEqual(config.Labels, upstreamConfig.Labels):
+		return &gkeapi.UpdateNodePoolRequest{
+			Labels: &gkeapi.NodeLabels{
+				Labels: config.Labels,
+			},
+		}, "labels"
+	case config.Taints != nil && !taintsEqual(config.Taints, upstreamConfig.Taints):
+		return &gkeapi.UpdateNodePoolRequest{
+			Taints: &gkeapi.NodeTaints{
+				Taints: newNodeTaints(config.Taints),
+			},
+		}, "taints"
+	case config.Tags != nil && !stringSetsEqual(config.Tags, upstreamConfig.Tags):
+		return &gkeapi.UpdateNodePoolRequest{
+			Tags: &gkeapi.NetworkTags{
+				Tags: config.Tags,
+			},
+		}, "tags"
+	case config.ResourceLabels != nil && !labelsEqual(config.ResourceLabels, upstreamConfig.ResourceLabels):
+		return &gkeapi.UpdateNodePoolRequest{
+			ResourceLabels: &gkeapi.ResourceLabels{
+				Labels: config.ResourceLabels,
+			},
+		}, "resource labels"
+	case network.EnablePrivateNodes != nil && (upstreamNetwork.EnablePrivateNodes == nil || *network.EnablePrivateNodes != *upstreamNetwork.EnablePrivateNodes):
+		return &gkeapi.UpdateNodePoolRequest{
+			NodeNetworkConfig: &gkeapi.NodeNetworkConfig{
+				EnablePrivateNodes: *network.EnablePrivateNodes,
+				ForceSendFields:    []string{"EnablePrivateNodes"},
+			},
+		}, "private nodes"
+	case workloadMetadata != "" && workloadMetadata != workloadMetadataMode(upstreamNodePool):
+		return &gkeapi.UpdateNodePoolRequest{
+			WorkloadMetadataConfig: &gkeapi.WorkloadMetadataConfig{
+				Mode: workloadMetadata,
+			},
+		}, "workload metadata"
+	}
+	return nil, ""
+}
+
+// nodePoolBootDiskKmsKey returns the key used to encrypt the boot disks of the node pool. A key set
+// for the node pool takes precedence over the customer managed encryption key of the cluster.
+func nodePoolBootDiskKmsKey(np *gkev1.GKENodePoolConfig, config *gkev1.GKEClusterConfig) string {
+	if np.Config.BootDiskKmsKey != "" {
+		return np.Config.BootDiskKmsKey
+	}
+	if config.Spec.CustomerManagedEncryptionKey != nil &&
+		config.Spec.CustomerManagedEncryptionKey.RingName != "" &&
+		config.Spec.CustomerManagedEncryptionKey.KeyName != "" {
+		return BootDiskRRN(
+			config.Spec.ProjectID,
+			Location(config.Spec.Region, config.Spec.Zone),
+			config.Spec.CustomerManagedEncryptionKey.RingName,
+			config.Spec.CustomerManagedEncryptionKey.KeyName,
+		)
+	}
+	return ""
+}
+
+// ValidateNodePoolUpdate returns an error if the spec of an existing node pool changes fields that
+// GKE cannot update in place. Fields left empty in the spec are defaulted by GKE, so they are not
+// compared.
+func ValidateNodePoolUpdate(nodePool *gkev1.GKENodePoolConfig, config *gkev1.GKEClusterConfig, upstreamNodePool *gkev1.GKENodePoolConfig) error {
+	network := nodePool.NetworkConfig
+	if network == nil {
+		network = &gkev1.GKENodeNetworkConfig{}
+	}
+	upstreamNetwork := upstreamNodePool.NetworkConfig
+	if upstreamNetwork == nil {
+		upstreamNetwork = &gkev1.GKENodeNetworkConfig{}
+	}
+	npConfig := nodePool.Config
+	upstreamConfig := upstreamNodePool.Config
+	bootDiskKmsKey := ""
+	if npConfig == nil || upstreamConfig == nil {
+		npConfig, upstreamConfig = &gkev1.GKENodeConfig{}, &gkev1.GKENodeConfig{}
+	} else {
+		bootDiskKmsKey = nodePoolBootDiskKmsKey(nodePool, config)
+	}
+
+	field := ""
+	switch {
+	case network.PodRange != "" && network.PodRange != upstreamNetwork.PodRange:
+		field = "networkConfig.podRange"
+	case network.CreatePodRange && network.PodIpv4CidrBlock != "" && network.PodIpv4CidrBlock != upstreamNetwork.PodIpv4CidrBlock:
+		field = "networkConfig.podIpv4CidrBlock"
+	case npConfig.LocalSsdCount != upstreamConfig.LocalSsdCount:
+		field = "localSsdCount"
+	case npConfig.Preemptible != upstreamConfig.Preemptible:
+		field = "preemptible"
+	case npConfig.Spot != upstreamConfig.Spot:
+		field = "spot"
+	case npConfig.Accelerators != nil && !acceleratorsEqual(npConfig.Accelerators, upstreamConfig.Accelerators):
+		field = "accelerators"
+	case npConfig.ServiceAccount != "" && !serviceAccountsEqual(npConfig.ServiceAccount, upstreamConfig.ServiceAccount):
+		field = "serviceAccount"
+	case len(npConfig.OauthScopes) != 0 && !stringSetsEqual(npConfig.OauthScopes, upstreamConfig.OauthScopes):
+		field = "oauthScopes"
+	case bootDiskKmsKey != "" && bootDiskKmsKey != upstreamConfig.BootDiskKmsKey:
+		field = "bootDiskKmsKey"
+	case npConfig.ShieldedInstanceConfig != nil && !shieldedInstanceConfigEqual(npConfig.ShieldedInstanceConfig, upstreamConfig.ShieldedInstanceConfig):
+		field = "shieldedInstanceConfig"
+	default:
+		return nil
+	}
+	return fmt.Errorf("field [%s] of node pool [%s] in cluster [%s (id: %s)] cannot be updated in place, set replacementPolicy to %s to replace the node pool", field, utils.StringValue(nodePool.Name), config.Spec.ClusterName, config.Name, NodePoolReplacementPolicyReplace)
+}
+
+// ValidateNodePoolConfig returns an error if the node config of the node pool sets options that
+// cannot be combined.
+func ValidateNodePoolConfig(nodePool *gkev1.GKENodePoolConfig, config *gkev1.GKEClusterConfig) error {
+	if nodePool.Config == nil {
+		return nil
+	}
+	if nodePool.Config.Preemptible && nodePool.Config.Spot {
+		return fmt.Errorf("only one of preemptible or spot can be set for node pool [%s] in cluster [%s (id: %s)]", utils.StringValue(nodePool.Name), config.Spec.ClusterName, config.Name)
+	}
+	return validateAccelerators(nodePool, config)
+}
+
+// serviceAccountsEqual compares two service accounts, an empty service account is the default one.
+func serviceAccountsEqual(lh, rh string) bool {
+	if lh == "" {
+		lh = "default"
+	}
+	if rh == "" {
+		rh = "default"
+	}
+	return lh == rh
+}
@@ -0,0 +1,197 @@
+package gke
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/gke/services"
+)
+
+// Operation statuses
+const (
+	// OperationStatusDone The DONE state indicates the operation has finished,
+	// either successfully or with an error.
+	OperationStatusDone = "DONE"
+
+	// OperationStatusAborting The ABORTING state indicates the operation is being
+	// cancelled.
+	OperationStatusAborting = "ABORTING"
+)
+
+// Operation progress metrics
+const (
+	metricNodesTotal = "NODES_TOTAL"
+	metricNodesDone  = "NODES_DONE"
+)
+
+// OperationRecorder wraps a GKEClusterService and records the last long-running operation
+// started through it, so the caller can wait on that operation instead of polling the
+// cluster and node pool statuses.
+type OperationRecorder struct {
+	services.GKEClusterService
+	operation *gkeapi.Operation
+}
+
+// NewOperationRecorder returns an OperationRecorder wrapping the given client.
+func NewOperationRecorder(gkeClient services.GKEClusterService) *OperationRecorder {
+	return &OperationRecorder{GKEClusterService: gkeClient}
+}
+
+// Operation returns the last operation started through the recorder, or nil if none was started.
+func (r *OperationRecorder) Operation() *gkeapi.Operation {
+	return r.operation
+}
+
+func (r *OperationRecorder) record(operation *gkeapi.Operation, err error) (*gkeapi.Operation, error) {
+	if err == nil && operation != nil {
+		r.operation = operation
+	}
+	return operation, err
+}
+
+func (r *OperationRecorder) ClusterCreate(ctx context.Context, parent string, createclusterrequest *gkeapi.CreateClusterRequest) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.ClusterCreate(ctx, parent, createclusterrequest))
+}
+
+func (r *OperationRecorder) ClusterUpdate(ctx context.Context, name string, updateclusterrequest *gkeapi.UpdateClusterRequest) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.ClusterUpdate(ctx, name, updateclusterrequest))
+}
+
+func (r *OperationRecorder) ClusterDelete(ctx context.Context, name string) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.ClusterDelete(ctx, name))
+}
+
+func (r *OperationRecorder) SetNetworkPolicy(ctx context.Context, name string, networkpolicyrequest *gkeapi.SetNetworkPolicyRequest) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.SetNetworkPolicy(ctx, name, networkpolicyrequest))
+}
+
+func (r *OperationRecorder) SetMaintenancePolicy(ctx context.Context, name string, maintenancepolicyrequest *gkeapi.SetMaintenancePolicyRequest) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.SetMaintenancePolicy(ctx, name, maintenancepolicyrequest))
+}
+
+func (r *OperationRecorder) SetResourceLabels(ctx context.Context, name string, resourcelabelsrequest *gkeapi.SetLabelsRequest) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.SetResourceLabels(ctx, name, resourcelabelsrequest))
+}
+
+func (r *OperationRecorder) NodePoolCreate(ctx context.Context, parent string, createnodepoolrequest *gkeapi.CreateNodePoolRequest) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.NodePoolCreate(ctx, parent, createnodepoolrequest))
+}
+
+func (r *OperationRecorder) NodePoolUpdate(ctx context.Context, name string, updatenodepoolrequest *gkeapi.UpdateNodePoolRequest) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.NodePoolUpdate(ctx, name, updatenodepoolrequest))
+}
+
+func (r *OperationRecorder) NodePoolDelete(ctx context.Context, name string) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.NodePoolDelete(ctx, name))
+}
+
+func (r *OperationRecorder) SetSize(ctx context.Context, name string, setnodepoolsizerequest *gkeapi.SetNodePoolSizeRequest) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.SetSize(ctx, name, setnodepoolsizerequest))
+}
+
+func (r *OperationRecorder) SetAutoscaling(ctx context.Context, name string, setnodepoolautoscalingrequest *gkeapi.SetNodePoolAutoscalingRequest) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.SetAutoscaling(ctx, name, setnodepoolautoscalingrequest))
+}
+
+func (r *OperationRecorder) SetManagement(ctx context.Context, name string, setnodepoolmanagementrequest *gkeapi.SetNodePoolManagementRequest) (*gkeapi.Operation, error) {
+	return r.record(r.GKEClusterService.SetManagement(ctx, name, setnodepoolmanagementrequest))
+}
+
+// GetOperation returns the operation with the given name in the cluster's location, or nil if
+// the operation no longer exists.
+func GetOperation(ctx context.Context, gkeClient services.GKEClusterService, configSpec *gkev1.GKEClusterConfigSpec, name string) (*gkeapi.Operation, error) {
+	op, err := gkeClient.OperationGet(ctx,
+		OperationRRN(configSpec.ProjectID, Location(configSpec.Region, configSpec.Zone), name))
+	if err != nil && strings.Contains(err.Error(), errNotFound) {
+		return nil, nil
+	}
+	return op, err
+}
+
+// CancelOperation cancels the operation with the given name in the cluster's location.
+func CancelOperation(ctx context.Context, gkeClient services.GKEClusterService, configSpec *gkev1.GKEClusterConfigSpec, name string) error {
+	_, err := gkeClient.OperationCancel(ctx,
+		OperationRRN(configSpec.ProjectID, Location(configSpec.Region, configSpec.Zone), name))
+	return err
+}
+
+// FindPendingOperation returns the first operation in the cluster's location that targets the
+// cluster and is not done, or nil if there is none. This is used to find operations that were
+// not started by the controller, such as auto-upgrades.
+func FindPendingOperation(ctx context.Context, gkeClient services.GKEClusterService, configSpec *gkev1.GKEClusterConfigSpec) (*gkeapi.Operation, error) {
+	location := Location(configSpec.Region, configSpec.Zone)
+	resp, err := gkeClient.OperationList(ctx, LocationRRN(configSpec.ProjectID, location))
+	if err != nil {
+		return nil, err
+	}
+	// the target link may use the project number and zones instead of locations, so only the
+	// cluster part of the link is matched
+	clusterPath := "/clusters/" + configSpec.ClusterName
+	for _, op := range resp.Operations {
+		if op.Status == OperationStatusDone {
+			continue
+		}
+		if strings.HasSuffix(op.TargetLink, clusterPath) || strings.Contains(op.TargetLink, clusterPath+"/") {
+			return op, nil
+		}
+	}
+	return nil, nil
+}
+
+// OperationError returns an error if the given operation finished unsuccessfully. The operation's
+// error is used when it is set, otherwise its status message, which older API responses use to
+// report failures.
+func OperationError(op *gkeapi.Operation) error {
+	message := op.StatusMessage
+	if op.Error != nil && (op.Error.Code != 0 || op.Error.Message != "") {
+		message = op.Error.Message
+		if message == "" {
+			message = fmt.Sprintf("error code %d", op.Error.Code)
+		}
+	}
+	if message == "" {
+		return nil
+	}
+	return fmt.Errorf("operation %s [%s] failed: %s", op.Name, op.OperationType, message)
+}
+
+// BuildOperationStatus returns the status representation of the given operation, or nil if
+// there is no operation to wait on.
+func BuildOperationStatus(op *gkeapi.Operation) *gkev1.GKEOperation {
+	if op == nil || op.Name == "" {
+		return nil
+	}
+	return &gkev1.GKEOperation{
+		Name:          op.Name,
+		OperationType: op.OperationType,
+		TargetLink:    op.TargetLink,
+		Status:        op.Status,
+		Progress:      operationProgress(op),
+		StartTime:     op.StartTime,
+	}
+}
+
+// operationProgress summarizes the progress of an operation as the number of nodes done out of
+// the total when GKE reports it, falling back to the progress status.
+func operationProgress(op *gkeapi.Operation) string {
+	if op.Progress == nil {
+		return ""
+	}
+	var done, total int64
+	for _, metric := range op.Progress.Metrics {
+		switch metric.Name {
+		case metricNodesDone:
+			done = metric.IntValue
+		case metricNodesTotal:
+			total = metric.IntValue
+		}
+	}
+	if total > 0 {
+		return fmt.Sprintf("%d/%d nodes", done, total)
+	}
+	return op.Progress.Status
+}
@@ -0,0 +1,218 @@
+package gke
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/gke/services/mock_services"
+	gkeapi "google.golang.org/api/container/v1"
+)
+
+var _ = Describe("Operations", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		configSpec         = &gkev1.GKEClusterConfigSpec{
+			Region:      "test-region",
+			ProjectID:   "test-project",
+			ClusterName: "test-cluster",
+		}
+		clusterRRN   = ClusterRRN(configSpec.ProjectID, Location(configSpec.Region, configSpec.Zone), configSpec.ClusterName)
+		operationRRN = OperationRRN(configSpec.ProjectID, Location(configSpec.Region, configSpec.Zone), "operation-1")
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should record the last started operation", func() {
+		recorder := NewOperationRecorder(clusterServiceMock)
+		Expect(recorder.Operation()).To(BeNil())
+
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(ctx, clusterRRN, &gkeapi.UpdateClusterRequest{}).
+			Return(&gkeapi.Operation{Name: "operation-1"}, nil)
+		clusterServiceMock.EXPECT().
+			NodePoolDelete(ctx, clusterRRN+"/nodePools/test-pool").
+			Return(&gkeapi.Operation{Name: "operation-2"}, nil)
+		clusterServiceMock.EXPECT().
+			SetSize(ctx, clusterRRN+"/nodePools/test-pool", &gkeapi.SetNodePoolSizeRequest{}).
+			Return(nil, errors.New(errWait))
+
+		_, err := recorder.ClusterUpdate(ctx, clusterRRN, &gkeapi.UpdateClusterRequest{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Operation().Name).To(Equal("operation-1"))
+
+		_, err = recorder.NodePoolDelete(ctx, clusterRRN+"/nodePools/test-pool")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recorder.Operation().Name).To(Equal("operation-2"))
+
+		// failed requests do not start an operation
+		_, err = recorder.SetSize(ctx, clusterRRN+"/nodePools/test-pool", &gkeapi.SetNodePoolSizeRequest{})
+		Expect(err).To(HaveOccurred())
+		Expect(recorder.Operation().Name).To(Equal("operation-2"))
+	})
+
+	It("should get an operation", func() {
+		clusterServiceMock.EXPECT().
+			OperationGet(ctx, operationRRN).
+			Return(&gkeapi.Operation{Name: "operation-1", Status: "RUNNING"}, nil)
+
+		op, err := GetOperation(ctx, clusterServiceMock, configSpec, "operation-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(op.Name).To(Equal("operation-1"))
+	})
+
+	It("should return nil if the operation doesn't exist", func() {
+		clusterServiceMock.EXPECT().
+			OperationGet(ctx, operationRRN).
+			Return(nil, errors.New("googleapi: Error 404: Not found: operation-1., notFound"))
+
+		op, err := GetOperation(ctx, clusterServiceMock, configSpec, "operation-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(op).To(BeNil())
+	})
+
+	It("should return other errors getting an operation", func() {
+		clusterServiceMock.EXPECT().
+			OperationGet(ctx, operationRRN).
+			Return(nil, errors.New("googleapi: Error 403: Permission denied, forbidden"))
+
+		_, err := GetOperation(ctx, clusterServiceMock, configSpec, "operation-1")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should cancel an operation", func() {
+		clusterServiceMock.EXPECT().
+			OperationCancel(ctx, operationRRN).
+			Return(&gkeapi.Empty{}, nil)
+
+		Expect(CancelOperation(ctx, clusterServiceMock, configSpec, "operation-1")).To(Succeed())
+	})
+
+	It("should find a pending operation targeting the cluster", func() {
+		clusterServiceMock.EXPECT().
+			OperationList(ctx, LocationRRN(configSpec.ProjectID, Location(configSpec.Region, configSpec.Zone))).
+			Return(&gkeapi.ListOperationsResponse{
+				Operations: []*gkeapi.Operation{
+					{
+						Name:       "operation-done",
+						Status:     OperationStatusDone,
+						TargetLink: "https://container.googleapis.com/v1/projects/123/zones/test-region/clusters/test-cluster",
+					},
+					{
+						Name:       "operation-other",
+						Status:     "RUNNING",
+						TargetLink: "https://container.googleapis.com/v1/projects/123/zones/test-region/clusters/test-cluster-2",
+					},
+					{
+						Name:       "operation-1",
+						Status:     "RUNNING",
+						TargetLink: "https://container.googleapis.com/v1/projects/123/zones/test-region/clusters/test-cluster/nodePools/test-pool",
+					},
+				},
+			}, nil)
+
+		op, err := FindPendingOperation(ctx, clusterServiceMock, configSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(op.Name).To(Equal("operation-1"))
+	})
+
+	It("should return nil if no operation is pending", func() {
+		clusterServiceMock.EXPECT().
+			OperationList(ctx, LocationRRN(configSpec.ProjectID, Location(configSpec.Region, configSpec.Zone))).
+			Return(&gkeapi.ListOperationsResponse{
+				Operations: []*gkeapi.Operation{
+					{
+						Name:       "operation-other",
+						Status:     "RUNNING",
+						TargetLink: "https://container.googleapis.com/v1/projects/123/zones/test-region/clusters/test-cluster-2",
+					},
+				},
+			}, nil)
+
+		op, err := FindPendingOperation(ctx, clusterServiceMock, configSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(op).To(BeNil())
+	})
+
+	It("should only return an error for failed operations", func() {
+		Expect(OperationError(&gkeapi.Operation{Status: OperationStatusDone})).To(Succeed())
+
+		err := OperationError(&gkeapi.Operation{
+			Name:          "operation-1",
+			OperationType: "UPDATE_CLUSTER",
+			Status:        OperationStatusDone,
+			Error:         &gkeapi.Status{Code: 9, Message: "Insufficient quota"},
+		})
+		Expect(err).To(MatchError("operation operation-1 [UPDATE_CLUSTER] failed: Insufficient quota"))
+
+		err = OperationError(&gkeapi.Operation{
+			Name:          "operation-1",
+			OperationType: "UPDATE_CLUSTER",
+			Status:        OperationStatusDone,
+			Error:         &gkeapi.Status{Code: 13},
+		})
+		Expect(err).To(MatchError("operation operation-1 [UPDATE_CLUSTER] failed: error code 13"))
+
+		err = OperationError(&gkeapi.Operation{
+			Name:          "operation-1",
+			OperationType: "UPDATE_CLUSTER",
+			Status:        OperationStatusDone,
+			StatusMessage: "Insufficient quota",
+		})
+		Expect(err).To(MatchError("operation operation-1 [UPDATE_CLUSTER] failed: Insufficient quota"))
+
+		err = OperationError(&gkeapi.Operation{
+			Name:          "operation-1",
+			OperationType: "UPDATE_CLUSTER",
+			Status:        OperationStatusDone,
+			Error:         &gkeapi.Status{},
+			StatusMessage: "Insufficient quota",
+		})
+		Expect(err).To(MatchError("operation operation-1 [UPDATE_CLUSTER] failed: Insufficient quota"))
+	})
+
+	It("should build the operation status", func() {
+		Expect(BuildOperationStatus(nil)).To(BeNil())
+		Expect(BuildOperationStatus(&gkeapi.Operation{})).To(BeNil())
+
+		operation := BuildOperationStatus(&gkeapi.Operation{
+			Name:          "operation-1",
+			OperationType: "UPGRADE_NODES",
+			TargetLink:    "https://container.googleapis.com/v1/projects/123/zones/test-region/clusters/test-cluster/nodePools/test-pool",
+			Status:        "RUNNING",
+			StartTime:     "2024-01-01T00:00:00Z",
+			Progress: &gkeapi.OperationProgress{
+				Status: "RUNNING",
+				Metrics: []*gkeapi.Metric{
+					{Name: "NODES_TOTAL", IntValue: 4},
+					{Name: "NODES_DONE", IntValue: 1},
+				},
+			},
+		})
+		Expect(*operation).To(Equal(gkev1.GKEOperation{
+			Name:          "operation-1",
+			OperationType: "UPGRADE_NODES",
+			TargetLink:    "https://container.googleapis.com/v1/projects/123/zones/test-region/clusters/test-cluster/nodePools/test-pool",
+			Status:        "RUNNING",
+			Progress:      "1/4 nodes",
+			StartTime:     "2024-01-01T00:00:00Z",
+		}))
+
+		operation = BuildOperationStatus(&gkeapi.Operation{
+			Name:     "operation-2",
+			Progress: &gkeapi.OperationProgress{Status: "RUNNING"},
+		})
+		Expect(operation.Progress).To(Equal("RUNNING"))
+	})
+})
@@ -0,0 +1,128 @@
+package gke
+
+import (
+	"fmt"
+	"slices"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/utils"
+)
+
+func newNodeNetworkConfig(networkConfig *gkev1.GKENodeNetworkConfig) *gkeapi.NodeNetworkConfig {
+	if networkConfig == nil {
+		return nil
+	}
+	nodeNetworkConfig := &gkeapi.NodeNetworkConfig{
+		PodRange:       networkConfig.PodRange,
+		CreatePodRange: networkConfig.CreatePodRange,
+	}
+	// GKE reports the IP range of existing pod ranges as well, it is only used to create a new range
+	if networkConfig.CreatePodRange {
+		nodeNetworkConfig.PodIpv4CidrBlock = networkConfig.PodIpv4CidrBlock
+	}
+	if networkConfig.EnablePrivateNodes != nil {
+		nodeNetworkConfig.EnablePrivateNodes = *networkConfig.EnablePrivateNodes
+		nodeNetworkConfig.ForceSendFields = []string{"EnablePrivateNodes"}
+	}
+	return nodeNetworkConfig
+}
+
+// BuildNodeNetworkConfig returns the spec representation of the given GKE node pool network
+// configuration.
+func BuildNodeNetworkConfig(networkConfig *gkeapi.NodeNetworkConfig) *gkev1.GKENodeNetworkConfig {
+	if networkConfig == nil {
+		return nil
+	}
+	enablePrivateNodes := networkConfig.EnablePrivateNodes
+	return &gkev1.GKENodeNetworkConfig{
+		PodRange:           networkConfig.PodRange,
+		PodIpv4CidrBlock:   networkConfig.PodIpv4CidrBlock,
+		CreatePodRange:     networkConfig.CreatePodRange,
+		EnablePrivateNodes: &enablePrivateNodes,
+	}
+}
+
+// BuildAdditionalPodRangesConfig returns the spec representation of the given GKE additional pod
+// ranges.
+func BuildAdditionalPodRangesConfig(podRanges *gkeapi.AdditionalPodRangesConfig) *gkev1.GKEAdditionalPodRangesConfig {
+	if podRanges == nil {
+		return &gkev1.GKEAdditionalPodRangesConfig{}
+	}
+	return &gkev1.GKEAdditionalPodRangesConfig{
+		PodRangeNames: podRanges.PodRangeNames,
+	}
+}
+
+// podRangesDiff returns the pod ranges of the spec that are missing upstream, and the upstream pod
+// ranges that are no longer in the spec.
+func podRangesDiff(podRanges, upstreamPodRanges []string) (added, removed []string) {
+	for _, podRange := range podRanges {
+		if !slices.Contains(upstreamPodRanges, podRange) {
+			added = append(added, podRange)
+		}
+	}
+	for _, podRange := range upstreamPodRanges {
+		if !slices.Contains(podRanges, podRange) {
+			removed = append(removed, podRange)
+		}
+	}
+	return added, removed
+}
+
+// validateAdditionalPodRanges checks that the additional pod ranges are unique and do not overlap
+// the primary pod and services ranges of the cluster.
+func validateAdditionalPodRanges(config *gkev1.GKEClusterConfig) error {
+	policy := config.Spec.IPAllocationPolicy
+	if policy == nil || policy.AdditionalPodRangesConfig == nil || len(policy.AdditionalPodRangesConfig.PodRangeNames) == 0 {
+		return nil
+	}
+	clusterName := config.Spec.ClusterName
+	if !policy.UseIPAliases {
+		return fmt.Errorf("IP aliases must be enabled when using additional pod ranges for cluster [%s (id: %s)]", clusterName, config.Name)
+	}
+	podRanges := make(map[string]bool, len(policy.AdditionalPodRangesConfig.PodRangeNames))
+	for _, podRange := range policy.AdditionalPodRangesConfig.PodRangeNames {
+		if podRange == "" {
+			return fmt.Errorf("additional pod range names cannot be empty for cluster [%s (id: %s)]", clusterName, config.Name)
+		}
+		if podRanges[podRange] {
+			return fmt.Errorf("additional pod range [%s] is not unique within the cluster [%s (id: %s)]", podRange, clusterName, config.Name)
+		}
+		podRanges[podRange] = true
+		if podRange == policy.ClusterSecondaryRangeName || podRange == policy.ServicesSecondaryRangeName {
+			return fmt.Errorf("pod range conflict: additional pod range [%s] is already used as the cluster or services secondary range for cluster [%s (id: %s)]", podRange, clusterName, config.Name)
+		}
+	}
+	return nil
+}
+
+// validateNodeNetworkConfig checks that the pod range of the node pool is either an existing range
+// or a new one, and that it does not overlap the services range of the cluster.
+func validateNodeNetworkConfig(np *gkev1.GKENodePoolConfig, config *gkev1.GKEClusterConfig) error {
+	networkConfig := np.NetworkConfig
+	if networkConfig == nil || (networkConfig.PodRange == "" && networkConfig.PodIpv4CidrBlock == "" && !networkConfig.CreatePodRange) {
+		return nil
+	}
+	npName := utils.StringValue(np.Name)
+	clusterName := config.Spec.ClusterName
+	policy := config.Spec.IPAllocationPolicy
+	if policy == nil || !policy.UseIPAliases {
+		return fmt.Errorf("IP aliases must be enabled when using a pod range for node pool [%s] in cluster [%s (id: %s)]", npName, clusterName, config.Name)
+	}
+	if networkConfig.CreatePodRange {
+		if networkConfig.PodRange == "" && networkConfig.PodIpv4CidrBlock == "" {
+			return fmt.Errorf("podRange or podIpv4CidrBlock must be set to create a pod range for node pool [%s] in cluster [%s (id: %s)]", npName, clusterName, config.Name)
+		}
+	} else if networkConfig.PodRange == "" {
+		return fmt.Errorf("podRange must be set unless createPodRange is set for node pool [%s] in cluster [%s (id: %s)]", npName, clusterName, config.Name)
+	}
+	if networkConfig.PodRange != "" && networkConfig.PodRange == policy.ServicesSecondaryRangeName {
+		return fmt.Errorf("pod range conflict: pod range [%s] of node pool [%s] is already used as the services secondary range for cluster [%s (id: %s)]", networkConfig.PodRange, npName, clusterName, config.Name)
+	}
+	if networkConfig.CreatePodRange && networkConfig.PodIpv4CidrBlock != "" && networkConfig.PodIpv4CidrBlock == policy.ServicesIpv4CidrBlock {
+		return fmt.Errorf("pod range conflict: podIpv4CidrBlock of node pool [%s] is already used as the services CIDR block for cluster [%s (id: %s)]", npName, clusterName, config.Name)
+	}
+	return nil
+}
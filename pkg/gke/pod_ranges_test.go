@@ -0,0 +1,120 @@
+package gke
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	gkeapi "google.golang.org/api/container/v1"
+)
+
+var _ = Describe("PodRanges", func() {
+	var (
+		boolTrue = true
+		config   *gkev1.GKEClusterConfig
+		nodePool *gkev1.GKENodePoolConfig
+	)
+
+	BeforeEach(func() {
+		nodePoolName := "test-node-pool"
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName: "test-cluster",
+				IPAllocationPolicy: &gkev1.GKEIPAllocationPolicy{
+					UseIPAliases:               true,
+					ClusterSecondaryRangeName:  "pods",
+					ServicesSecondaryRangeName: "services",
+					ServicesIpv4CidrBlock:      "10.4.0.0/14",
+					AdditionalPodRangesConfig: &gkev1.GKEAdditionalPodRangesConfig{
+						PodRangeNames: []string{"pods-2", "pods-3"},
+					},
+				},
+			},
+		}
+		nodePool = &gkev1.GKENodePoolConfig{
+			Name: &nodePoolName,
+			NetworkConfig: &gkev1.GKENodeNetworkConfig{
+				PodRange:           "pods-2",
+				EnablePrivateNodes: &boolTrue,
+			},
+		}
+	})
+
+	It("should round-trip the node pool network config", func() {
+		networkConfig := newNodeNetworkConfig(nodePool.NetworkConfig)
+		Expect(networkConfig).To(Equal(&gkeapi.NodeNetworkConfig{
+			PodRange:           "pods-2",
+			EnablePrivateNodes: true,
+			ForceSendFields:    []string{"EnablePrivateNodes"},
+		}))
+		Expect(BuildNodeNetworkConfig(networkConfig)).To(Equal(nodePool.NetworkConfig))
+		Expect(newNodeNetworkConfig(nil)).To(BeNil())
+		Expect(BuildNodeNetworkConfig(nil)).To(BeNil())
+	})
+
+	It("should only send the pod range CIDR when creating the pod range", func() {
+		nodePool.NetworkConfig.PodIpv4CidrBlock = "10.8.0.0/14"
+		Expect(newNodeNetworkConfig(nodePool.NetworkConfig).PodIpv4CidrBlock).To(BeEmpty())
+
+		nodePool.NetworkConfig.CreatePodRange = true
+		Expect(newNodeNetworkConfig(nodePool.NetworkConfig).PodIpv4CidrBlock).To(Equal("10.8.0.0/14"))
+	})
+
+	It("should diff the additional pod ranges", func() {
+		added, removed := podRangesDiff([]string{"pods-2", "pods-3"}, []string{"pods-3", "pods-4"})
+		Expect(added).To(Equal([]string{"pods-2"}))
+		Expect(removed).To(Equal([]string{"pods-4"}))
+
+		added, removed = podRangesDiff([]string{"pods-2"}, []string{"pods-2"})
+		Expect(added).To(BeEmpty())
+		Expect(removed).To(BeEmpty())
+	})
+
+	It("should validate the additional pod ranges", func() {
+		Expect(validateAdditionalPodRanges(config)).To(Succeed())
+
+		invalidConfig := config.DeepCopy()
+		invalidConfig.Spec.IPAllocationPolicy.UseIPAliases = false
+		Expect(validateAdditionalPodRanges(invalidConfig)).To(MatchError("IP aliases must be enabled when using additional pod ranges for cluster [test-cluster (id: )]"))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.IPAllocationPolicy.AdditionalPodRangesConfig.PodRangeNames = []string{"pods-2", "pods-2"}
+		Expect(validateAdditionalPodRanges(invalidConfig)).To(MatchError("additional pod range [pods-2] is not unique within the cluster [test-cluster (id: )]"))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.IPAllocationPolicy.AdditionalPodRangesConfig.PodRangeNames = []string{""}
+		Expect(validateAdditionalPodRanges(invalidConfig)).To(MatchError("additional pod range names cannot be empty for cluster [test-cluster (id: )]"))
+
+		invalidConfig = config.DeepCopy()
+		invalidConfig.Spec.IPAllocationPolicy.AdditionalPodRangesConfig.PodRangeNames = []string{"services"}
+		Expect(validateAdditionalPodRanges(invalidConfig)).To(MatchError("pod range conflict: additional pod range [services] is already used as the cluster or services secondary range for cluster [test-cluster (id: )]"))
+	})
+
+	It("should validate the node pool network config", func() {
+		Expect(validateNodeNetworkConfig(nodePool, config)).To(Succeed())
+
+		invalidNodePool := nodePool.DeepCopy()
+		invalidNodePool.NetworkConfig.PodRange = ""
+		Expect(validateNodeNetworkConfig(invalidNodePool, config)).To(Succeed())
+
+		invalidNodePool.NetworkConfig.PodIpv4CidrBlock = "10.8.0.0/14"
+		Expect(validateNodeNetworkConfig(invalidNodePool, config)).To(MatchError("podRange must be set unless createPodRange is set for node pool [test-node-pool] in cluster [test-cluster (id: )]"))
+
+		invalidNodePool.NetworkConfig.CreatePodRange = true
+		Expect(validateNodeNetworkConfig(invalidNodePool, config)).To(Succeed())
+
+		invalidNodePool.NetworkConfig.PodIpv4CidrBlock = ""
+		Expect(validateNodeNetworkConfig(invalidNodePool, config)).To(MatchError("podRange or podIpv4CidrBlock must be set to create a pod range for node pool [test-node-pool] in cluster [test-cluster (id: )]"))
+
+		invalidNodePool.NetworkConfig.PodIpv4CidrBlock = "10.4.0.0/14"
+		Expect(validateNodeNetworkConfig(invalidNodePool, config)).To(MatchError("pod range conflict: podIpv4CidrBlock of node pool [test-node-pool] is already used as the services CIDR block for cluster [test-cluster (id: )]"))
+
+		invalidNodePool = nodePool.DeepCopy()
+		invalidNodePool.NetworkConfig.PodRange = "services"
+		Expect(validateNodeNetworkConfig(invalidNodePool, config)).To(MatchError("pod range conflict: pod range [services] of node pool [test-node-pool] is already used as the services secondary range for cluster [test-cluster (id: )]"))
+
+		invalidConfig := config.DeepCopy()
+		invalidConfig.Spec.IPAllocationPolicy.UseIPAliases = false
+		Expect(validateNodeNetworkConfig(nodePool, invalidConfig)).To(MatchError("IP aliases must be enabled when using a pod range for node pool [test-node-pool] in cluster [test-cluster (id: )]"))
+	})
+})
@@ -0,0 +1,72 @@
+package gke
+
+import (
+	"fmt"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+)
+
+// NewPrivateClusterConfig returns the GKE private cluster configuration for the given spec.
+func NewPrivateClusterConfig(privateClusterConfig *gkev1.GKEPrivateClusterConfig) *gkeapi.PrivateClusterConfig {
+	newConfig := &gkeapi.PrivateClusterConfig{
+		EnablePrivateEndpoint:     privateClusterConfig.EnablePrivateEndpoint,
+		EnablePrivateNodes:        privateClusterConfig.EnablePrivateNodes,
+		MasterIpv4CidrBlock:       privateClusterConfig.MasterIpv4CidrBlock,
+		PrivateEndpointSubnetwork: privateClusterConfig.PrivateEndpointSubnetwork,
+	}
+	if privateClusterConfig.MasterGlobalAccessConfig != nil {
+		newConfig.MasterGlobalAccessConfig = newMasterGlobalAccessConfig(privateClusterConfig.MasterGlobalAccessConfig)
+	}
+	return newConfig
+}
+
+func newMasterGlobalAccessConfig(globalAccess *gkev1.GKEMasterGlobalAccessConfig) *gkeapi.PrivateClusterMasterGlobalAccessConfig {
+	return &gkeapi.PrivateClusterMasterGlobalAccessConfig{
+		Enabled:         globalAccess.Enabled,
+		ForceSendFields: []string{"Enabled"},
+	}
+}
+
+// BuildPrivateClusterConfig returns the spec representation of the given GKE private cluster
+// configuration.
+func BuildPrivateClusterConfig(privateClusterConfig *gkeapi.PrivateClusterConfig) *gkev1.GKEPrivateClusterConfig {
+	if privateClusterConfig == nil {
+		return &gkev1.GKEPrivateClusterConfig{
+			MasterGlobalAccessConfig: &gkev1.GKEMasterGlobalAccessConfig{},
+		}
+	}
+	return &gkev1.GKEPrivateClusterConfig{
+		EnablePrivateEndpoint:     privateClusterConfig.EnablePrivateEndpoint,
+		EnablePrivateNodes:        privateClusterConfig.EnablePrivateNodes,
+		MasterIpv4CidrBlock:       privateClusterConfig.MasterIpv4CidrBlock,
+		PrivateEndpointSubnetwork: privateClusterConfig.PrivateEndpointSubnetwork,
+		MasterGlobalAccessConfig: &gkev1.GKEMasterGlobalAccessConfig{
+			Enabled: privateClusterConfig.MasterGlobalAccessConfig != nil && privateClusterConfig.MasterGlobalAccessConfig.Enabled,
+		},
+	}
+}
+
+func validatePrivateClusterConfig(config *gkev1.GKEClusterConfig) error {
+	privateClusterConfig := config.Spec.PrivateClusterConfig
+	if privateClusterConfig == nil {
+		return nil
+	}
+	clusterName := config.Spec.ClusterName
+	if privateClusterConfig.EnablePrivateEndpoint && !privateClusterConfig.EnablePrivateNodes {
+		return fmt.Errorf("private endpoint requires private nodes for cluster [%s (id: %s)]", clusterName, config.Name)
+	}
+	if privateClusterConfig.MasterGlobalAccessConfig != nil && privateClusterConfig.MasterGlobalAccessConfig.Enabled && !privateClusterConfig.EnablePrivateNodes {
+		return fmt.Errorf("master global access requires private nodes for cluster [%s (id: %s)]", clusterName, config.Name)
+	}
+	if privateClusterConfig.PrivateEndpointSubnetwork != "" {
+		if !privateClusterConfig.EnablePrivateNodes {
+			return fmt.Errorf("privateEndpointSubnetwork requires private nodes for cluster [%s (id: %s)]", clusterName, config.Name)
+		}
+		if privateClusterConfig.MasterIpv4CidrBlock != "" {
+			return fmt.Errorf("cannot specify both masterIpv4CidrBlock and privateEndpointSubnetwork for cluster [%s (id: %s)]", clusterName, config.Name)
+		}
+	}
+	return nil
+}
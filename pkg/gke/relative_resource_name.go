@@ -41,3 +41,9 @@ func NodePoolRRN(projectID, location, clusterName, nodePool string) string {
 func BootDiskRRN(projectID, location, ringName, keyName string) string {
 	return fmt.Sprintf("projects/%s/locations/%s/keyRings/%s/cryptoKeys/%s", projectID, location, ringName, keyName)
 }
+
+// OperationRRN returns a Relative Resource Name of an operation in the region or zone
+// for the specified project
+func OperationRRN(projectID, location, operation string) string {
+	return fmt.Sprintf("%s/operations/%s", LocationRRN(projectID, location), operation)
+}
@@ -25,6 +25,9 @@ type GKEClusterService interface {
 	SetSize(ctx context.Context, name string, setnodepoolsizerequest *gkeapi.SetNodePoolSizeRequest) (*gkeapi.Operation, error)
 	SetAutoscaling(ctx context.Context, name string, setnodepoolautoscalingrequest *gkeapi.SetNodePoolAutoscalingRequest) (*gkeapi.Operation, error)
 	SetManagement(ctx context.Context, name string, setnodepoolmanagementrequest *gkeapi.SetNodePoolManagementRequest) (*gkeapi.Operation, error)
+	OperationGet(ctx context.Context, name string) (*gkeapi.Operation, error)
+	OperationList(ctx context.Context, parent string) (*gkeapi.ListOperationsResponse, error)
+	OperationCancel(ctx context.Context, name string) (*gkeapi.Empty, error)
 }
 
 type gkeClusterService struct {
@@ -104,3 +107,15 @@ func (g *gkeClusterService) SetAutoscaling(ctx context.Context, name string, set
 func (g *gkeClusterService) SetManagement(ctx context.Context, name string, setnodepoolmanagementrequest *gkeapi.SetNodePoolManagementRequest) (*gkeapi.Operation, error) {
 	return g.svc.Projects.Locations.Clusters.NodePools.SetManagement(name, setnodepoolmanagementrequest).Context(ctx).Do()
 }
+
+func (g *gkeClusterService) OperationGet(ctx context.Context, name string) (*gkeapi.Operation, error) {
+	return g.svc.Projects.Locations.Operations.Get(name).Context(ctx).Do()
+}
+
+func (g *gkeClusterService) OperationList(ctx context.Context, parent string) (*gkeapi.ListOperationsResponse, error) {
+	return g.svc.Projects.Locations.Operations.List(parent).Context(ctx).Do()
+}
+
+func (g *gkeClusterService) OperationCancel(ctx context.Context, name string) (*gkeapi.Empty, error) {
+	return g.svc.Projects.Locations.Operations.Cancel(name, &gkeapi.CancelOperationRequest{}).Context(ctx).Do()
+}
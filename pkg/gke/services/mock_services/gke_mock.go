@@ -185,6 +185,51 @@ func (mr *MockGKEClusterServiceMockRecorder) NodePoolUpdate(ctx, name, updatenod
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodePoolUpdate", reflect.TypeOf((*MockGKEClusterService)(nil).NodePoolUpdate), ctx, name, updatenodepoolrequest)
 }
 
+// OperationCancel mocks base method.
+func (m *MockGKEClusterService) OperationCancel(ctx context.Context, name string) (*container.Empty, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OperationCancel", ctx, name)
+	ret0, _ := ret[0].(*container.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OperationCancel indicates an expected call of OperationCancel.
+func (mr *MockGKEClusterServiceMockRecorder) OperationCancel(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OperationCancel", reflect.TypeOf((*MockGKEClusterService)(nil).OperationCancel), ctx, name)
+}
+
+// OperationGet mocks base method.
+func (m *MockGKEClusterService) OperationGet(ctx context.Context, name string) (*container.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OperationGet", ctx, name)
+	ret0, _ := ret[0].(*container.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OperationGet indicates an expected call of OperationGet.
+func (mr *MockGKEClusterServiceMockRecorder) OperationGet(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OperationGet", reflect.TypeOf((*MockGKEClusterService)(nil).OperationGet), ctx, name)
+}
+
+// OperationList mocks base method.
+func (m *MockGKEClusterService) OperationList(ctx context.Context, parent string) (*container.ListOperationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OperationList", ctx, parent)
+	ret0, _ := ret[0].(*container.ListOperationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OperationList indicates an expected call of OperationList.
+func (mr *MockGKEClusterServiceMockRecorder) OperationList(ctx, parent interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OperationList", reflect.TypeOf((*MockGKEClusterService)(nil).OperationList), ctx, parent)
+}
+
 // SetAutoscaling mocks base method.
 func (m *MockGKEClusterService) SetAutoscaling(ctx context.Context, name string, setnodepoolautoscalingrequest *container.SetNodePoolAutoscalingRequest) (*container.Operation, error) {
 	m.ctrl.T.Helper()
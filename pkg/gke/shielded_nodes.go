@@ -0,0 +1,73 @@
+package gke
+
+import (
+	"fmt"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+)
+
+// NewShieldedNodes returns the GKE shielded nodes configuration for the given spec. The field is
+// always sent as GKE enables shielded nodes by default.
+func NewShieldedNodes(shieldedNodes *gkev1.GKEShieldedNodes) *gkeapi.ShieldedNodes {
+	return &gkeapi.ShieldedNodes{
+		Enabled:         shieldedNodes.Enabled,
+		ForceSendFields: []string{"Enabled"},
+	}
+}
+
+// BuildShieldedNodes returns the spec representation of the given GKE shielded nodes configuration.
+func BuildShieldedNodes(shieldedNodes *gkeapi.ShieldedNodes) *gkev1.GKEShieldedNodes {
+	return &gkev1.GKEShieldedNodes{
+		Enabled: shieldedNodes != nil && shieldedNodes.Enabled,
+	}
+}
+
+// NewShieldedInstanceConfig returns the GKE shielded instance configuration of a node pool for the
+// given spec. Both fields are always sent as GKE enables integrity monitoring by default.
+func NewShieldedInstanceConfig(shieldedInstanceConfig *gkev1.GKEShieldedInstanceConfig) *gkeapi.ShieldedInstanceConfig {
+	return &gkeapi.ShieldedInstanceConfig{
+		EnableIntegrityMonitoring: shieldedInstanceConfig.EnableIntegrityMonitoring,
+		EnableSecureBoot:          shieldedInstanceConfig.EnableSecureBoot,
+		ForceSendFields:           []string{"EnableIntegrityMonitoring", "EnableSecureBoot"},
+	}
+}
+
+// BuildShieldedInstanceConfig returns the spec representation of the given GKE shielded instance
+// configuration of a node pool.
+func BuildShieldedInstanceConfig(shieldedInstanceConfig *gkeapi.ShieldedInstanceConfig) *gkev1.GKEShieldedInstanceConfig {
+	if shieldedInstanceConfig == nil {
+		return nil
+	}
+	return &gkev1.GKEShieldedInstanceConfig{
+		EnableIntegrityMonitoring: shieldedInstanceConfig.EnableIntegrityMonitoring,
+		EnableSecureBoot:          shieldedInstanceConfig.EnableSecureBoot,
+	}
+}
+
+// shieldedInstanceConfigEqual returns true if the upstream shielded instance configuration of a node
+// pool matches the spec. Node pools created before the fields were force sent omitted the false
+// values, so GKE enabled integrity monitoring on them even when the spec disabled it. That
+// configuration is accepted as equal, otherwise every such node pool would be reported as changed.
+// As a consequence, disabling integrity monitoring on an existing node pool is not detected.
+func shieldedInstanceConfigEqual(shieldedInstanceConfig, upstream *gkev1.GKEShieldedInstanceConfig) bool {
+	if upstream == nil {
+		upstream = &gkev1.GKEShieldedInstanceConfig{}
+	}
+	if *shieldedInstanceConfig == *upstream {
+		return true
+	}
+	created := gkev1.GKEShieldedInstanceConfig{
+		EnableIntegrityMonitoring: true,
+		EnableSecureBoot:          shieldedInstanceConfig.EnableSecureBoot,
+	}
+	return !shieldedInstanceConfig.EnableIntegrityMonitoring && created == *upstream
+}
+
+func validateShieldedNodes(config *gkev1.GKEClusterConfig) error {
+	if config.Spec.ShieldedNodes == nil || config.Spec.ShieldedNodes.Enabled || !IsAutopilot(config) {
+		return nil
+	}
+	return fmt.Errorf(notSupportedForAutopilotError, "shieldedNodes", config.Spec.ClusterName, config.Name)
+}
@@ -23,6 +23,30 @@ const (
 	NetworkProviderCalico = "CALICO"
 )
 
+// Datapath Providers
+const (
+	// DatapathProviderLegacy is the iptables-based datapath, with network policies enforced by Calico
+	DatapathProviderLegacy = "LEGACY_DATAPATH"
+	// DatapathProviderAdvanced is the eBPF-based Dataplane V2, which enforces network policies itself
+	DatapathProviderAdvanced = "ADVANCED_DATAPATH"
+)
+
+// Stack Types
+const (
+	// StackTypeIPv4 assigns IPv4 addresses only
+	StackTypeIPv4 = "IPV4"
+	// StackTypeIPv4IPv6 assigns both IPv4 and IPv6 addresses (dual-stack)
+	StackTypeIPv4IPv6 = "IPV4_IPV6"
+)
+
+// IPv6 Access Types
+const (
+	// IPv6AccessTypeInternal makes the IPv6 addresses reachable from within the VPC only
+	IPv6AccessTypeInternal = "INTERNAL"
+	// IPv6AccessTypeExternal makes the IPv6 addresses reachable from the internet
+	IPv6AccessTypeExternal = "EXTERNAL"
+)
+
 // Logging Services
 const (
 	// CloudLoggingService is the Cloud Logging service with a Kubernetes-native resource model
@@ -35,6 +59,74 @@ const (
 	CloudMonitoringService = "monitoring.googleapis.com/kubernetes"
 )
 
+// Release Channels
+const (
+	// ReleaseChannelUnspecified means the cluster is not enrolled in a release channel
+	ReleaseChannelUnspecified = "UNSPECIFIED"
+	// ReleaseChannelRapid gets the newest Kubernetes releases as early as possible
+	ReleaseChannelRapid = "RAPID"
+	// ReleaseChannelRegular gets Kubernetes releases a few months after they are available in RAPID
+	ReleaseChannelRegular = "REGULAR"
+	// ReleaseChannelStable gets Kubernetes releases after they are proven stable in REGULAR
+	ReleaseChannelStable = "STABLE"
+	// ReleaseChannelExtended is like REGULAR, with an extended support period for each minor version
+	ReleaseChannelExtended = "EXTENDED"
+)
+
+// InReleaseChannel returns true if the spec enrolls the cluster in a release channel, in which case
+// GKE upgrades the control plane and the node pools automatically.
+func InReleaseChannel(spec *gkev1.GKEClusterConfigSpec) bool {
+	channel := utils.StringValue(spec.ReleaseChannel)
+	return channel != "" && channel != ReleaseChannelUnspecified
+}
+
+// isAutoUpgraded returns true if the upstream version is newer than the spec version of a cluster
+// enrolled in a release channel. This is expected, as GKE upgrades such clusters on its own, and
+// must not be treated as drift.
+func isAutoUpgraded(spec *gkev1.GKEClusterConfigSpec, specVersion, upstreamVersion string) bool {
+	if !InReleaseChannel(spec) {
+		return false
+	}
+	sv, err := semv.NewVersion(specVersion)
+	if err != nil {
+		return false
+	}
+	uv, err := semv.NewVersion(upstreamVersion)
+	if err != nil {
+		return false
+	}
+	return uv.GreaterThan(sv)
+}
+
+// UpdateReleaseChannel updates the release channel the cluster is enrolled in.
+func UpdateReleaseChannel(ctx context.Context, gkeClient services.GKEClusterService, config *gkev1.GKEClusterConfig, upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
+	channel := utils.StringValue(config.Spec.ReleaseChannel)
+	if channel == "" {
+		return NotChanged, nil
+	}
+
+	if utils.StringValue(upstreamSpec.ReleaseChannel) == channel {
+		return NotChanged, nil
+	}
+
+	logrus.Infof("Updating release channel to %s for cluster [%s (id: %s)]", channel, config.Spec.ClusterName, config.Name)
+	logrus.Debugf("config: %s; upstream: %s", channel, utils.StringValue(upstreamSpec.ReleaseChannel))
+	_, err := gkeClient.ClusterUpdate(ctx,
+		ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+		&gkeapi.UpdateClusterRequest{
+			Update: &gkeapi.ClusterUpdate{
+				DesiredReleaseChannel: &gkeapi.ReleaseChannel{
+					Channel: channel,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
 // UpdateMasterKubernetesVersion updates the Kubernetes version for the control plane.
 // This must occur before the Kubernetes version is changed on the nodes.
 func UpdateMasterKubernetesVersion(ctx context.Context, gkeClient services.GKEClusterService, config *gkev1.GKEClusterConfig, upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
@@ -57,6 +149,11 @@ func UpdateMasterKubernetesVersion(ctx context.Context, gkeClient services.GKECl
 		return NotChanged, err
 	}
 
+	if isAutoUpgraded(&config.Spec, kubeVersion, *upstreamSpec.KubernetesVersion) {
+		logrus.Debugf("Cluster [%s (id: %s)] was upgraded to %s by its release channel, spec version is %s", config.Spec.ClusterName, config.Name, upstreamVersion, specVersion)
+		return NotChanged, nil
+	}
+
 	// Check if the minor version is being downgraded https://cloud.google.com/kubernetes-engine/docs/how-to/upgrading-a-cluster#downgrading-limitations
 	if specVersion.Major() == upstreamVersion.Major() && specVersion.Minor() < upstreamVersion.Minor() {
 		return NotChanged, fmt.Errorf("upstream version %q is higher than spec version %q and downgrades of minor versions are not supported in GKE, consider updating spec version to match upstream version", upstreamVersion, specVersion)
@@ -80,26 +177,32 @@ func UpdateMasterKubernetesVersion(ctx context.Context, gkeClient services.GKECl
 
 // UpdateClusterAddons updates the cluster addons.
 // In the case of the NetworkPolicyConfig addon, this may need to be retried after NetworkPolicyEnabled has been updated.
+// Vertical pod autoscaling is updated once the other addons match the spec, since GKE only accepts
+// one kind of update per request.
 func UpdateClusterAddons(ctx context.Context, gkeClient services.GKEClusterService, config *gkev1.GKEClusterConfig, upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
 	clusterUpdate := &gkeapi.ClusterUpdate{}
 	addons := config.Spec.ClusterAddons
-	if addons == nil {
+	// the addons are managed by GKE for Autopilot clusters and cannot be updated
+	if addons == nil || IsAutopilot(config) {
 		return NotChanged, nil
 	}
+	if err := validateClusterAddons(config); err != nil {
+		return NotChanged, err
+	}
+	if addons.NetworkPolicyConfig && dataplaneV2(upstreamSpec) {
+		return NotChanged, fmt.Errorf(notSupportedForDataplaneV2Error, "clusterAddons.networkPolicyConfig", config.Spec.ClusterName, config.Name)
+	}
 
+	desiredAddons := &gkeapi.AddonsConfig{}
 	needsUpdate := false
 	if upstreamSpec.ClusterAddons.HTTPLoadBalancing != addons.HTTPLoadBalancing {
-		clusterUpdate.DesiredAddonsConfig = &gkeapi.AddonsConfig{}
-		clusterUpdate.DesiredAddonsConfig.HttpLoadBalancing = &gkeapi.HttpLoadBalancing{
+		desiredAddons.HttpLoadBalancing = &gkeapi.HttpLoadBalancing{
 			Disabled: !addons.HTTPLoadBalancing,
 		}
 		needsUpdate = true
 	}
 	if upstreamSpec.ClusterAddons.HorizontalPodAutoscaling != addons.HorizontalPodAutoscaling {
-		if clusterUpdate.DesiredAddonsConfig == nil {
-			clusterUpdate.DesiredAddonsConfig = &gkeapi.AddonsConfig{}
-		}
-		clusterUpdate.DesiredAddonsConfig.HorizontalPodAutoscaling = &gkeapi.HorizontalPodAutoscaling{
+		desiredAddons.HorizontalPodAutoscaling = &gkeapi.HorizontalPodAutoscaling{
 			Disabled: !addons.HorizontalPodAutoscaling,
 		}
 		needsUpdate = true
@@ -113,15 +216,22 @@ func UpdateClusterAddons(ctx context.Context, gkeClient services.GKEClusterServi
 		if !addons.NetworkPolicyConfig && !*config.Spec.NetworkPolicyEnabled && *upstreamSpec.NetworkPolicyEnabled {
 			logrus.Infof("Waiting to update NetworkPolicyConfig cluster addon")
 		} else {
-			if clusterUpdate.DesiredAddonsConfig == nil {
-				clusterUpdate.DesiredAddonsConfig = &gkeapi.AddonsConfig{}
-			}
-			clusterUpdate.DesiredAddonsConfig.NetworkPolicyConfig = &gkeapi.NetworkPolicyConfig{
+			desiredAddons.NetworkPolicyConfig = &gkeapi.NetworkPolicyConfig{
 				Disabled: !addons.NetworkPolicyConfig,
 			}
 			needsUpdate = true
 		}
 	}
+	if setOptionalAddons(desiredAddons, addons, upstreamSpec.ClusterAddons) {
+		needsUpdate = true
+	}
+
+	if needsUpdate {
+		clusterUpdate.DesiredAddonsConfig = desiredAddons
+	} else if addonNeedsUpdate(addons.VerticalPodAutoscaling, upstreamSpec.ClusterAddons.VerticalPodAutoscaling) {
+		clusterUpdate.DesiredVerticalPodAutoscaling = NewVerticalPodAutoscaling(addons)
+		needsUpdate = true
+	}
 
 	if needsUpdate {
 		logrus.Infof("Updating addon configuration to %+v for cluster [%s (id: %s)]", *config.Spec.ClusterAddons, config.Spec.ClusterName, config.Name)
@@ -153,6 +263,52 @@ func UpdateClusterAddons(ctx context.Context, gkeClient services.GKEClusterServi
 	return NotChanged, nil
 }
 
+// UpdateDatabaseEncryption encrypts the secrets of the cluster with the Cloud KMS key of the spec,
+// rotates that key or decrypts them. While GKE is encrypting or decrypting the secrets, it returns
+// Retry so that no other update is sent until it is done.
+func UpdateDatabaseEncryption(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	config *gkev1.GKEClusterConfig,
+	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
+	upstreamDatabaseEncryption := upstreamSpec.DatabaseEncryption
+	if databaseEncryptionPending(upstreamDatabaseEncryption) {
+		logrus.Infof("Waiting for database encryption to finish for cluster [%s (id: %s)], current state: %s", config.Spec.ClusterName, config.Name, upstreamDatabaseEncryption.CurrentState)
+		return Retry, nil
+	}
+	databaseEncryption := config.Spec.DatabaseEncryption
+	if databaseEncryption == nil || databaseEncryption.State == "" {
+		return NotChanged, nil
+	}
+	if upstreamDatabaseEncryption == nil {
+		upstreamDatabaseEncryption = &gkev1.GKEDatabaseEncryption{}
+	}
+	if databaseEncryptionEqual(databaseEncryption, upstreamDatabaseEncryption) {
+		return NotChanged, nil
+	}
+	if err := validateDatabaseEncryption(config); err != nil {
+		return NotChanged, err
+	}
+
+	logrus.Infof("Updating database encryption to %s for cluster [%s (id: %s)]", databaseEncryption.State, config.Spec.ClusterName, config.Name)
+	logrus.Debugf("config: %+v; upstream: %+v", *databaseEncryption, *upstreamDatabaseEncryption)
+	_, err := gkeClient.ClusterUpdate(ctx,
+		ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+		&gkeapi.UpdateClusterRequest{
+			Update: &gkeapi.ClusterUpdate{
+				DesiredDatabaseEncryption: &gkeapi.DatabaseEncryption{
+					State:   databaseEncryption.State,
+					KeyName: databaseEncryption.KeyName,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
 // UpdateMasterAuthorizedNetworks updates MasterAuthorizedNetworks
 func UpdateMasterAuthorizedNetworks(
 	ctx context.Context,
@@ -206,6 +362,94 @@ func UpdateMasterAuthorizedNetworks(
 	return NotChanged, nil
 }
 
+// UpdatePrivateClusterConfig updates the private endpoint and the master global access of a private
+// cluster. Only one of them is updated at a time, the other one is updated on the next reconcile
+// loop. The remaining private cluster fields cannot be changed after creation.
+func UpdatePrivateClusterConfig(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	config *gkev1.GKEClusterConfig,
+	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
+	privateClusterConfig := config.Spec.PrivateClusterConfig
+	if privateClusterConfig == nil {
+		return NotChanged, nil
+	}
+	upstreamPrivateClusterConfig := upstreamSpec.PrivateClusterConfig
+	if upstreamPrivateClusterConfig == nil {
+		upstreamPrivateClusterConfig = &gkev1.GKEPrivateClusterConfig{}
+	}
+	upstreamGlobalAccess := upstreamPrivateClusterConfig.MasterGlobalAccessConfig
+	if upstreamGlobalAccess == nil {
+		upstreamGlobalAccess = &gkev1.GKEMasterGlobalAccessConfig{}
+	}
+
+	clusterUpdate := &gkeapi.ClusterUpdate{}
+	switch {
+	case privateClusterConfig.EnablePrivateEndpoint != upstreamPrivateClusterConfig.EnablePrivateEndpoint:
+		clusterUpdate.DesiredEnablePrivateEndpoint = privateClusterConfig.EnablePrivateEndpoint
+		clusterUpdate.ForceSendFields = []string{"DesiredEnablePrivateEndpoint"}
+	case privateClusterConfig.MasterGlobalAccessConfig != nil && privateClusterConfig.MasterGlobalAccessConfig.Enabled != upstreamGlobalAccess.Enabled:
+		clusterUpdate.DesiredPrivateClusterConfig = &gkeapi.PrivateClusterConfig{
+			MasterGlobalAccessConfig: newMasterGlobalAccessConfig(privateClusterConfig.MasterGlobalAccessConfig),
+		}
+	default:
+		return NotChanged, nil
+	}
+	if err := validatePrivateClusterConfig(config); err != nil {
+		return NotChanged, err
+	}
+
+	logrus.Infof("Updating private cluster configuration to %+v for cluster [%s (id: %s)]", *privateClusterConfig, config.Spec.ClusterName, config.Name)
+	logrus.Debugf("config: %+v; upstream: %+v", *privateClusterConfig, *upstreamPrivateClusterConfig)
+	_, err := gkeClient.ClusterUpdate(ctx,
+		ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+		&gkeapi.UpdateClusterRequest{
+			Update: clusterUpdate,
+		},
+	)
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
+// UpdateControlPlaneEndpoints updates the DNS-based and IP-based endpoints of the control plane.
+func UpdateControlPlaneEndpoints(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	config *gkev1.GKEClusterConfig,
+	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
+	if config.Spec.ControlPlaneEndpointsConfig == nil {
+		return NotChanged, nil
+	}
+	upstreamEndpointsConfig := upstreamSpec.ControlPlaneEndpointsConfig
+	if upstreamEndpointsConfig == nil {
+		upstreamEndpointsConfig = &gkev1.GKEControlPlaneEndpointsConfig{}
+	}
+	endpointsConfig := newControlPlaneEndpointsUpdate(config.Spec.ControlPlaneEndpointsConfig, upstreamEndpointsConfig)
+	if endpointsConfig == nil {
+		return NotChanged, nil
+	}
+	if err := validateControlPlaneEndpoints(config); err != nil {
+		return NotChanged, err
+	}
+
+	logrus.Infof("Updating control plane endpoints configuration for cluster [%s (id: %s)]", config.Spec.ClusterName, config.Name)
+	logrus.Debugf("config: %+v; upstream: %+v", *config.Spec.ControlPlaneEndpointsConfig, *upstreamEndpointsConfig)
+	_, err := gkeClient.ClusterUpdate(ctx,
+		ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+		&gkeapi.UpdateClusterRequest{
+			Update: &gkeapi.ClusterUpdate{
+				DesiredControlPlaneEndpointsConfig: endpointsConfig,
+			},
+		},
+	)
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
 // UpdateLoggingMonitoringService updates both LoggingService and MonitoringService.
 // In most cases, updating one requires explicitly updating the other as well, so these are paired.
 func UpdateLoggingMonitoringService(
@@ -259,7 +503,14 @@ func UpdateNetworkPolicyEnabled(
 	gkeClient services.GKEClusterService,
 	config *gkev1.GKEClusterConfig,
 	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
-	if config.Spec.NetworkPolicyEnabled == nil {
+	// network policies are enforced by Dataplane V2 for Autopilot clusters
+	if config.Spec.NetworkPolicyEnabled == nil || IsAutopilot(config) {
+		return NotChanged, nil
+	}
+	if dataplaneV2(upstreamSpec) {
+		if *config.Spec.NetworkPolicyEnabled {
+			return NotChanged, fmt.Errorf(notSupportedForDataplaneV2Error, "networkPolicyEnabled", config.Spec.ClusterName, config.Name)
+		}
 		return NotChanged, nil
 	}
 
@@ -283,23 +534,28 @@ func UpdateNetworkPolicyEnabled(
 	return NotChanged, nil
 }
 
+// dataplaneV2 returns true if the upstream cluster uses Dataplane V2, which enforces network
+// policies itself.
+func dataplaneV2(upstreamSpec *gkev1.GKEClusterConfigSpec) bool {
+	return upstreamSpec.DatapathProvider != nil && *upstreamSpec.DatapathProvider == DatapathProviderAdvanced
+}
+
 // UpdateLocations updates Locations.
 func UpdateLocations(
 	ctx context.Context,
 	gkeClient services.GKEClusterService,
 	config *gkev1.GKEClusterConfig,
 	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
-	if config.Spec.Zone == "" {
-		// Editing default node zones is available only in zonal clusters.
-		return NotChanged, nil
-	}
-
 	clusterUpdate := &gkeapi.ClusterUpdate{}
 
 	locations := config.Spec.Locations
 	sort.Strings(locations)
 	upstreamLocations := upstreamSpec.Locations
 	sort.Strings(upstreamLocations)
+	if len(locations) == 0 && config.Spec.Zone == "" {
+		// the zones of a regional cluster are chosen by GKE when none are specified
+		return NotChanged, nil
+	}
 	location := Location(config.Spec.Region, config.Spec.Zone)
 	if len(locations) == 0 && len(upstreamLocations) == 1 && strings.HasPrefix(upstreamLocations[0], location) {
 		// special case: no additional locations specified, upstream locations
@@ -332,7 +588,8 @@ func UpdateMaintenanceWindow(
 	gkeClient services.GKEClusterService,
 	config *gkev1.GKEClusterConfig,
 	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
-	if config.Spec.MaintenanceWindow == nil {
+	// the maintenance policy takes precedence, see UpdateMaintenancePolicy
+	if config.Spec.MaintenanceWindow == nil || config.Spec.MaintenancePolicy != nil {
 		return NotChanged, nil
 	}
 	window := utils.StringValue(config.Spec.MaintenanceWindow)
@@ -362,6 +619,279 @@ func UpdateMaintenanceWindow(
 	return Changed, nil
 }
 
+// UpdateMaintenancePolicy updates Cluster.MaintenancePolicy with the daily or recurring window and
+// the maintenance exclusions. The upstream resource version is sent along, so GKE rejects the update
+// if the policy was changed concurrently.
+func UpdateMaintenancePolicy(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	config *gkev1.GKEClusterConfig,
+	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
+	if config.Spec.MaintenancePolicy == nil {
+		return NotChanged, nil
+	}
+	if maintenancePoliciesEqual(config.Spec.MaintenancePolicy, upstreamSpec.MaintenancePolicy) {
+		return NotChanged, nil
+	}
+
+	policy := NewMaintenancePolicy(config.Spec.MaintenancePolicy)
+	if upstreamSpec.MaintenancePolicy != nil {
+		policy.ResourceVersion = upstreamSpec.MaintenancePolicy.ResourceVersion
+	}
+	logrus.Infof("Updating maintenance policy to %+v for cluster [%s (id: %s)]", *config.Spec.MaintenancePolicy, config.Spec.ClusterName, config.Name)
+	logrus.Debugf("config: %+v; upstream: %+v", config.Spec.MaintenancePolicy, upstreamSpec.MaintenancePolicy)
+	_, err := gkeClient.SetMaintenancePolicy(ctx,
+		ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+		&gkeapi.SetMaintenancePolicyRequest{
+			MaintenancePolicy: policy,
+		},
+	)
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
+// UpdateClusterAutoscaling updates the cluster-wide autoscaling, including node auto-provisioning.
+func UpdateClusterAutoscaling(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	config *gkev1.GKEClusterConfig,
+	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
+	if config.Spec.ClusterAutoscaling == nil {
+		return NotChanged, nil
+	}
+	if err := validateClusterAutoscaling(config); err != nil {
+		return NotChanged, err
+	}
+	if clusterAutoscalingEqual(config.Spec.ClusterAutoscaling, upstreamSpec.ClusterAutoscaling) {
+		return NotChanged, nil
+	}
+
+	logrus.Infof("Updating cluster autoscaling to %+v for cluster [%s (id: %s)]", *config.Spec.ClusterAutoscaling, config.Spec.ClusterName, config.Name)
+	logrus.Debugf("config: %+v; upstream: %+v", config.Spec.ClusterAutoscaling, upstreamSpec.ClusterAutoscaling)
+	_, err := gkeClient.ClusterUpdate(ctx,
+		ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+		&gkeapi.UpdateClusterRequest{
+			Update: &gkeapi.ClusterUpdate{
+				DesiredClusterAutoscaling: NewClusterAutoscaling(config.Spec.ClusterAutoscaling),
+			},
+		},
+	)
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
+// UpdateDNSConfig updates the DNS configuration of the cluster. Existing nodes pick up the new
+// configuration once they are recreated.
+func UpdateDNSConfig(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	config *gkev1.GKEClusterConfig,
+	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
+	if config.Spec.DNSConfig == nil {
+		return NotChanged, nil
+	}
+	if err := validateDNSConfig(config); err != nil {
+		return NotChanged, err
+	}
+	if dnsConfigEqual(config.Spec.DNSConfig, upstreamSpec.DNSConfig) {
+		return NotChanged, nil
+	}
+
+	logrus.Infof("Updating DNS config to %+v for cluster [%s (id: %s)]", *config.Spec.DNSConfig, config.Spec.ClusterName, config.Name)
+	logrus.Debugf("config: %+v; upstream: %+v", config.Spec.DNSConfig, upstreamSpec.DNSConfig)
+	_, err := gkeClient.ClusterUpdate(ctx,
+		ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+		&gkeapi.UpdateClusterRequest{
+			Update: &gkeapi.ClusterUpdate{
+				DesiredDnsConfig: NewDNSConfig(config.Spec.DNSConfig),
+			},
+		},
+	)
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
+// UpdateStackType updates the IP stack type of the cluster.
+func UpdateStackType(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	config *gkev1.GKEClusterConfig,
+	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
+	if config.Spec.IPAllocationPolicy == nil || config.Spec.IPAllocationPolicy.StackType == "" {
+		return NotChanged, nil
+	}
+	stackType := config.Spec.IPAllocationPolicy.StackType
+	if upstreamSpec.IPAllocationPolicy != nil && upstreamSpec.IPAllocationPolicy.StackType == stackType {
+		return NotChanged, nil
+	}
+	if err := validateStackType(config, IsAutopilot(config) || dataplaneV2(upstreamSpec)); err != nil {
+		return NotChanged, err
+	}
+
+	logrus.Infof("Updating stack type to %s for cluster [%s (id: %s)]", stackType, config.Spec.ClusterName, config.Name)
+	_, err := gkeClient.ClusterUpdate(ctx,
+		ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+		&gkeapi.UpdateClusterRequest{
+			Update: &gkeapi.ClusterUpdate{
+				DesiredStackType: stackType,
+			},
+		},
+	)
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
+// UpdateAdditionalPodRanges adds or removes the additional pod ranges of the cluster. GKE only accepts
+// one of both operations per request, so additions are sent first and removals on the next reconcile.
+func UpdateAdditionalPodRanges(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	config *gkev1.GKEClusterConfig,
+	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
+	if config.Spec.IPAllocationPolicy == nil || config.Spec.IPAllocationPolicy.AdditionalPodRangesConfig == nil {
+		return NotChanged, nil
+	}
+	var upstreamPodRanges []string
+	if upstreamSpec.IPAllocationPolicy != nil && upstreamSpec.IPAllocationPolicy.AdditionalPodRangesConfig != nil {
+		upstreamPodRanges = upstreamSpec.IPAllocationPolicy.AdditionalPodRangesConfig.PodRangeNames
+	}
+	added, removed := podRangesDiff(config.Spec.IPAllocationPolicy.AdditionalPodRangesConfig.PodRangeNames, upstreamPodRanges)
+	if len(added) == 0 && len(removed) == 0 {
+		return NotChanged, nil
+	}
+	if err := validateAdditionalPodRanges(config); err != nil {
+		return NotChanged, err
+	}
+
+	update := &gkeapi.ClusterUpdate{}
+	if len(added) != 0 {
+		logrus.Infof("Adding additional pod ranges %v for cluster [%s (id: %s)]", added, config.Spec.ClusterName, config.Name)
+		update.AdditionalPodRangesConfig = &gkeapi.AdditionalPodRangesConfig{
+			PodRangeNames: added,
+		}
+	} else {
+		logrus.Infof("Removing additional pod ranges %v for cluster [%s (id: %s)]", removed, config.Spec.ClusterName, config.Name)
+		update.RemovedAdditionalPodRangesConfig = &gkeapi.AdditionalPodRangesConfig{
+			PodRangeNames: removed,
+		}
+	}
+	logrus.Debugf("config: %+v; upstream: %+v", config.Spec.IPAllocationPolicy.AdditionalPodRangesConfig, upstreamPodRanges)
+	_, err := gkeClient.ClusterUpdate(ctx,
+		ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+		&gkeapi.UpdateClusterRequest{
+			Update: update,
+		},
+	)
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
+// UpdateShieldedNodes enables or disables shielded nodes. GKE recreates the nodes of all the node
+// pools to apply the change. Shielded nodes are always enabled for autopilot clusters.
+//
+// The setting is only changed once the spec differs from the configuration recorded in
+// Status.AppliedShieldedNodes. Clusters created before the field was force sent have shielded nodes
+// enabled regardless of the spec, that difference is reported rather than applied.
+func UpdateShieldedNodes(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	config *gkev1.GKEClusterConfig,
+	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
+	if config.Spec.ShieldedNodes == nil {
+		return NotChanged, nil
+	}
+	enabled := config.Spec.ShieldedNodes.Enabled
+	upstreamEnabled := upstreamSpec.ShieldedNodes != nil && upstreamSpec.ShieldedNodes.Enabled
+	if enabled == upstreamEnabled {
+		return NotChanged, nil
+	}
+	if applied := config.Status.AppliedShieldedNodes; applied == nil || applied.Enabled == enabled {
+		logrus.Warnf("Shielded nodes are %v for cluster [%s (id: %s)] but %v in the spec, edit the spec to apply it", upstreamEnabled, config.Spec.ClusterName, config.Name, enabled)
+		return NotChanged, nil
+	}
+	if err := validateShieldedNodes(config); err != nil {
+		return NotChanged, err
+	}
+
+	logrus.Infof("Updating shielded nodes to %v for cluster [%s (id: %s)]", enabled, config.Spec.ClusterName, config.Name)
+	logrus.Debugf("config: %v; upstream: %v", enabled, upstreamEnabled)
+	_, err := gkeClient.ClusterUpdate(ctx,
+		ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+		&gkeapi.UpdateClusterRequest{
+			Update: &gkeapi.ClusterUpdate{
+				DesiredShieldedNodes: NewShieldedNodes(config.Spec.ShieldedNodes),
+			},
+		},
+	)
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
+// UpdateWorkloadIdentity enables or disables workload identity. The node pools are switched to the
+// matching workload metadata mode by UpdateNodePoolConfig afterwards. When disabling it, the node
+// pools are switched back to the Compute Engine metadata server first.
+func UpdateWorkloadIdentity(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	config *gkev1.GKEClusterConfig,
+	upstreamSpec *gkev1.GKEClusterConfigSpec) (Status, error) {
+	if config.Spec.WorkloadIdentityConfig == nil {
+		return NotChanged, nil
+	}
+	workloadPool := config.Spec.WorkloadIdentityConfig.WorkloadPool
+	upstreamWorkloadPool := ""
+	if upstreamSpec.WorkloadIdentityConfig != nil {
+		upstreamWorkloadPool = upstreamSpec.WorkloadIdentityConfig.WorkloadPool
+	}
+	if workloadPool == upstreamWorkloadPool {
+		return NotChanged, nil
+	}
+	if err := validateWorkloadIdentity(config); err != nil {
+		return NotChanged, err
+	}
+	if err := validateClusterAddons(config); err != nil {
+		return NotChanged, err
+	}
+	if workloadPool == "" {
+		for i := range upstreamSpec.NodePools {
+			np := &upstreamSpec.NodePools[i]
+			if workloadMetadataMode(np) == WorkloadMetadataModeGKE {
+				logrus.Infof("Waiting for node pool [%s] to stop using the GKE metadata server before disabling workload identity for cluster [%s (id: %s)]", utils.StringValue(np.Name), config.Spec.ClusterName, config.Name)
+				return NotChanged, nil
+			}
+		}
+	}
+
+	logrus.Infof("Updating workload identity pool to %q for cluster [%s (id: %s)]", workloadPool, config.Spec.ClusterName, config.Name)
+	logrus.Debugf("config: %+v; upstream: %+v", workloadPool, upstreamWorkloadPool)
+	_, err := gkeClient.ClusterUpdate(ctx,
+		ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+		&gkeapi.UpdateClusterRequest{
+			Update: &gkeapi.ClusterUpdate{
+				DesiredWorkloadIdentityConfig: &gkeapi.WorkloadIdentityConfig{
+					WorkloadPool: workloadPool,
+				},
+			},
+		},
+	)
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
 // UpdateLabels updates the cluster labels.
 func UpdateLabels(
 	ctx context.Context,
@@ -412,7 +942,8 @@ func UpdateNodePoolKubernetesVersionOrImageType(
 	updateRequest := &gkeapi.UpdateNodePoolRequest{}
 	needsUpdate := false
 	npVersion := utils.StringValue(nodePool.Version)
-	if npVersion != "" && utils.StringValue(upstreamNodePool.Version) != npVersion {
+	if npVersion != "" && utils.StringValue(upstreamNodePool.Version) != npVersion &&
+		!isAutoUpgraded(&config.Spec, npVersion, utils.StringValue(upstreamNodePool.Version)) {
 		logrus.Infof("Updating kubernetes version of node pool [%s] to %s on cluster [%s (id: %s)]", utils.StringValue(nodePool.Name), npVersion, config.Spec.ClusterName, config.Name)
 		logrus.Debugf("config: %s; upstream: %s", npVersion, utils.StringValue(upstreamNodePool.Version))
 		updateRequest.NodeVersion = npVersion
@@ -441,6 +972,117 @@ func UpdateNodePoolKubernetesVersionOrImageType(
 	return NotChanged, nil
 }
 
+// UpdateNodePoolUpgradeSettings updates the upgrade strategy for a given node pool.
+// If the node pool is busy, it will return a Retry status indicating the operation should be retried later.
+func UpdateNodePoolUpgradeSettings(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	nodePool *gkev1.GKENodePoolConfig,
+	config *gkev1.GKEClusterConfig,
+	upstreamNodePool *gkev1.GKENodePoolConfig) (Status, error) {
+	if nodePool.UpgradeSettings == nil || upgradeSettingsEqual(nodePool.UpgradeSettings, upstreamNodePool.UpgradeSettings) {
+		return NotChanged, nil
+	}
+	if err := validateUpgradeSettings(nodePool, config); err != nil {
+		return NotChanged, err
+	}
+
+	logrus.Infof("Updating upgrade settings to %+v of node pool [%s] on cluster [%s (id: %s)]", *nodePool.UpgradeSettings, utils.StringValue(nodePool.Name), config.Spec.ClusterName, config.Name)
+	logrus.Debugf("config: %+v; upstream: %+v", nodePool.UpgradeSettings, upstreamNodePool.UpgradeSettings)
+	_, err := gkeClient.NodePoolUpdate(ctx,
+		NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, *nodePool.Name),
+		&gkeapi.UpdateNodePoolRequest{
+			UpgradeSettings: newUpgradeSettings(nodePool.UpgradeSettings),
+		})
+	if err != nil && strings.Contains(err.Error(), errWait) {
+		logrus.Debugf("error %v updating node pool, will retry", err)
+		return Retry, nil
+	}
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
+// UpdateNodePoolMachineConfig updates the machine type and boot disk of a given node pool. GKE applies these
+// changes by recreating the nodes of the node pool, following its upgrade settings.
+// If the node pool is busy, it will return a Retry status indicating the operation should be retried later.
+func UpdateNodePoolMachineConfig(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	nodePool *gkev1.GKENodePoolConfig,
+	config *gkev1.GKEClusterConfig,
+	upstreamNodePool *gkev1.GKENodePoolConfig) (Status, error) {
+	if nodePool.Config == nil || upstreamNodePool.Config == nil {
+		return NotChanged, nil
+	}
+
+	updateRequest := &gkeapi.UpdateNodePoolRequest{}
+	needsUpdate := false
+	if machineType := nodePool.Config.MachineType; machineType != "" && machineType != upstreamNodePool.Config.MachineType {
+		logrus.Infof("Updating machine type of node pool [%s] to %s on cluster [%s (id: %s)]", utils.StringValue(nodePool.Name), machineType, config.Spec.ClusterName, config.Name)
+		logrus.Debugf("config: %s; upstream: %s", machineType, upstreamNodePool.Config.MachineType)
+		updateRequest.MachineType = machineType
+		needsUpdate = true
+	}
+	if diskSizeGb := nodePool.Config.DiskSizeGb; diskSizeGb != 0 && diskSizeGb != upstreamNodePool.Config.DiskSizeGb {
+		logrus.Infof("Updating disk size of node pool [%s] to %d GB on cluster [%s (id: %s)]", utils.StringValue(nodePool.Name), diskSizeGb, config.Spec.ClusterName, config.Name)
+		logrus.Debugf("config: %d; upstream: %d", diskSizeGb, upstreamNodePool.Config.DiskSizeGb)
+		updateRequest.DiskSizeGb = diskSizeGb
+		needsUpdate = true
+	}
+	if diskType := nodePool.Config.DiskType; diskType != "" && diskType != upstreamNodePool.Config.DiskType {
+		logrus.Infof("Updating disk type of node pool [%s] to %s on cluster [%s (id: %s)]", utils.StringValue(nodePool.Name), diskType, config.Spec.ClusterName, config.Name)
+		logrus.Debugf("config: %s; upstream: %s", diskType, upstreamNodePool.Config.DiskType)
+		updateRequest.DiskType = diskType
+		needsUpdate = true
+	}
+	if needsUpdate {
+		_, err := gkeClient.NodePoolUpdate(ctx,
+			NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, *nodePool.Name),
+			updateRequest)
+		if err != nil && strings.Contains(err.Error(), errWait) {
+			logrus.Debugf("error %v updating node pool, will retry", err)
+			return Retry, nil
+		}
+		if err != nil {
+			return NotChanged, err
+		}
+		return Changed, nil
+	}
+	return NotChanged, nil
+}
+
+// UpdateNodePoolLocations updates the zones where the nodes of a given node pool run. Locations left
+// empty in the spec are not managed.
+// If the node pool is busy, it will return a Retry status indicating the operation should be retried later.
+func UpdateNodePoolLocations(
+	ctx context.Context,
+	gkeClient services.GKEClusterService,
+	nodePool *gkev1.GKENodePoolConfig,
+	config *gkev1.GKEClusterConfig,
+	upstreamNodePool *gkev1.GKENodePoolConfig) (Status, error) {
+	if len(nodePool.Locations) == 0 || stringSetsEqual(nodePool.Locations, upstreamNodePool.Locations) {
+		return NotChanged, nil
+	}
+
+	logrus.Infof("Updating locations of node pool [%s] to %v on cluster [%s (id: %s)]", utils.StringValue(nodePool.Name), nodePool.Locations, config.Spec.ClusterName, config.Name)
+	logrus.Debugf("config: %v; upstream: %v", nodePool.Locations, upstreamNodePool.Locations)
+	_, err := gkeClient.NodePoolUpdate(ctx,
+		NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, *nodePool.Name),
+		&gkeapi.UpdateNodePoolRequest{
+			Locations: nodePool.Locations,
+		})
+	if err != nil && strings.Contains(err.Error(), errWait) {
+		logrus.Debugf("error %v updating node pool, will retry", err)
+		return Retry, nil
+	}
+	if err != nil {
+		return NotChanged, err
+	}
+	return Changed, nil
+}
+
 // UpdateNodePoolSize sets the size of a given node pool.
 // If the node pool is busy, it will return a Retry status indicating the operation should be retried later.
 func UpdateNodePoolSize(
@@ -573,7 +1215,8 @@ func UpdateNodePoolManagement(
 	return NotChanged, nil
 }
 
-// UpdateNodePoolConfig updates the configured parameters for a given node pool.
+// UpdateNodePoolConfig updates the node labels, taints, network tags, resource labels, private nodes override
+// and workload metadata mode for a given node pool.
 // If the node pool is busy, it will return a Retry status indicating the operation should be retried later.
 func UpdateNodePoolConfig(
 	ctx context.Context,
@@ -581,17 +1224,12 @@ func UpdateNodePoolConfig(
 	nodePool *gkev1.GKENodePoolConfig,
 	config *gkev1.GKEClusterConfig,
 	upstreamNodePool *gkev1.GKENodePoolConfig) (Status, error) {
-	if nodePool.Config.Labels == nil || reflect.DeepEqual(nodePool.Config.Labels, upstreamNodePool.Config.Labels) || (upstreamNodePool.Config.Labels == nil && len(nodePool.Config.Labels) == 0) {
+	updateRequest, field := newNodePoolConfigUpdateRequest(nodePool, upstreamNodePool, config)
+	if updateRequest == nil {
 		return NotChanged, nil
 	}
 
-	updateRequest := &gkeapi.UpdateNodePoolRequest{
-		Labels: &gkeapi.NodeLabels{
-			Labels: nodePool.Config.Labels,
-		},
-	}
-
-	logrus.Infof("Updating config for node pool [%s] on cluster [%s (id: %s)]", utils.StringValue(nodePool.Name), config.Spec.ClusterName, config.Name)
+	logrus.Infof("Updating %s for node pool [%s] on cluster [%s (id: %s)]", field, utils.StringValue(nodePool.Name), config.Spec.ClusterName, config.Name)
 	_, err := gkeClient.NodePoolUpdate(ctx,
 		NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, *nodePool.Name),
 		updateRequest)
@@ -680,9 +1318,6 @@ func UpdateIntraNodeVisibility(
 }
 
 // Note: Most other security features are immutable after cluster creation:
-// - DatabaseEncryption: Cannot be changed after cluster creation
-// - ShieldedNodes: Cannot be changed after cluster creation
-// - WorkloadIdentityConfig: Cannot be changed after cluster creation
 // - LegacyAbac: Cannot be changed after cluster creation
 // - MasterAuth: Cannot be changed after cluster creation
 func GetCluster(ctx context.Context, gkeClient services.GKEClusterService, configSpec *gkev1.GKEClusterConfigSpec) (*gkeapi.Cluster, error) {
@@ -690,6 +1325,16 @@ func GetCluster(ctx context.Context, gkeClient services.GKEClusterService, confi
 		ClusterRRN(configSpec.ProjectID, Location(configSpec.Region, configSpec.Zone), configSpec.ClusterName))
 }
 
+// GetNodePool returns the node pool with the given name in the cluster, or nil if it doesn't exist.
+func GetNodePool(ctx context.Context, gkeClient services.GKEClusterService, configSpec *gkev1.GKEClusterConfigSpec, name string) (*gkeapi.NodePool, error) {
+	nodePool, err := gkeClient.NodePoolGet(ctx,
+		NodePoolRRN(configSpec.ProjectID, Location(configSpec.Region, configSpec.Zone), configSpec.ClusterName, name))
+	if err != nil && strings.Contains(err.Error(), errNotFound) {
+		return nil, nil
+	}
+	return nodePool, err
+}
+
 func compareCidrBlockPointerSlices(lh, rh []*gkev1.GKECidrBlock) bool {
 	if len(lh) != len(rh) {
 		return false
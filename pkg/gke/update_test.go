@@ -1,6 +1,8 @@
 package gke
 
 import (
+	"errors"
+
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -120,6 +122,109 @@ var _ = Describe("UpdateMasterKubernetesVersion", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(status).To(Equal(Changed))
 	})
+
+	It("should not downgrade a cluster upgraded by its release channel", func() {
+		channelConfig := config.DeepCopy()
+		releaseChannel := ReleaseChannelRegular
+		channelConfig.Spec.ReleaseChannel = &releaseChannel
+
+		upstreamSpec.KubernetesVersion = &higherUpstreamVersionRevision
+		status, err := UpdateMasterKubernetesVersion(ctx, clusterServiceMock, channelConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		upstreamSpec.KubernetesVersion = &higherUpstreamVersionMinor
+		status, err = UpdateMasterKubernetesVersion(ctx, clusterServiceMock, channelConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should upgrade a cluster in a release channel to a newer spec version", func() {
+		channelConfig := config.DeepCopy()
+		releaseChannel := ReleaseChannelRegular
+		channelConfig.Spec.ReleaseChannel = &releaseChannel
+
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredMasterVersion: k8sVersion,
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		upstreamSpec.KubernetesVersion = &oldVersion
+		status, err := UpdateMasterKubernetesVersion(ctx, clusterServiceMock, channelConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+})
+
+var _ = Describe("UpdateReleaseChannel", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		stableChannel      = ReleaseChannelStable
+		regularChannel     = ReleaseChannelRegular
+
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:         "test-region",
+				ProjectID:      "test-project",
+				ClusterName:    "test-cluster",
+				ReleaseChannel: &stableChannel,
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			ClusterName:    "test-cluster",
+			ReleaseChannel: &regularChannel,
+		}
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should update release channel", func() {
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredReleaseChannel: &gkeapi.ReleaseChannel{
+							Channel: ReleaseChannelStable,
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateReleaseChannel(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update release channel if it is not set", func() {
+		unsetConfig := config.DeepCopy()
+		unsetConfig.Spec.ReleaseChannel = nil
+		status, err := UpdateReleaseChannel(ctx, clusterServiceMock, unsetConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not update release channel", func() {
+		upstreamSpec.ReleaseChannel = &stableChannel
+		status, err := UpdateReleaseChannel(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
 })
 
 var _ = Describe("UpdateClusterAddons", func() {
@@ -212,6 +317,16 @@ var _ = Describe("UpdateClusterAddons", func() {
 		Expect(status).To(Equal(Changed))
 	})
 
+	It("should not change addons for autopilot cluster", func() {
+		autopilotConfig := config.DeepCopy()
+		autopilotConfig.Spec.AutopilotConfig = &gkev1.GKEAutopilotConfig{
+			Enabled: true,
+		}
+		status, err := UpdateClusterAddons(ctx, clusterServiceMock, autopilotConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
 	It("should not change addons", func() {
 		upstreamSpec.ClusterAddons.HTTPLoadBalancing = true
 		upstreamSpec.ClusterAddons.NetworkPolicyConfig = false
@@ -221,6 +336,84 @@ var _ = Describe("UpdateClusterAddons", func() {
 		Expect(status).To(Equal(NotChanged))
 	})
 
+	It("should change optional addons before vertical pod autoscaling", func() {
+		addonsConfig := config.DeepCopy()
+		addonsConfig.Spec.ClusterAddons.GcsFuseCsiDriver = &boolTrue
+		addonsConfig.Spec.ClusterAddons.VerticalPodAutoscaling = &boolTrue
+		boolFalse := false
+		addonsConfig.Spec.ClusterAddons.DNSCacheConfig = &boolFalse
+		addonsUpstreamSpec := upstreamSpec.DeepCopy()
+		addonsUpstreamSpec.ClusterAddons = &gkev1.GKEClusterAddons{
+			HTTPLoadBalancing:        true,
+			HorizontalPodAutoscaling: true,
+			GcsFuseCsiDriver:         &boolFalse,
+			DNSCacheConfig:           &boolFalse,
+			VerticalPodAutoscaling:   &boolFalse,
+		}
+
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredAddonsConfig: &gkeapi.AddonsConfig{
+							GcsFuseCsiDriverConfig: &gkeapi.GcsFuseCsiDriverConfig{
+								Enabled:         true,
+								ForceSendFields: []string{"Enabled"},
+							},
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateClusterAddons(ctx, clusterServiceMock, addonsConfig, addonsUpstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+
+		addonsUpstreamSpec.ClusterAddons.GcsFuseCsiDriver = &boolTrue
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredVerticalPodAutoscaling: &gkeapi.VerticalPodAutoscaling{
+							Enabled:         true,
+							ForceSendFields: []string{"Enabled"},
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err = UpdateClusterAddons(ctx, clusterServiceMock, addonsConfig, addonsUpstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+
+		addonsUpstreamSpec.ClusterAddons.VerticalPodAutoscaling = &boolTrue
+		status, err = UpdateClusterAddons(ctx, clusterServiceMock, addonsConfig, addonsUpstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not enable the network policy addon for Dataplane V2 cluster", func() {
+		addonsConfig := config.DeepCopy()
+		addonsConfig.Spec.ClusterAddons.NetworkPolicyConfig = true
+		datapathProvider := DatapathProviderAdvanced
+		addonsUpstreamSpec := upstreamSpec.DeepCopy()
+		addonsUpstreamSpec.DatapathProvider = &datapathProvider
+		status, err := UpdateClusterAddons(ctx, clusterServiceMock, addonsConfig, addonsUpstreamSpec)
+		Expect(err).To(MatchError("field [clusterAddons.networkPolicyConfig] is not supported for Dataplane V2 cluster [test-cluster (id: )], which enforces network policies itself"))
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not enable config connector without workload identity", func() {
+		addonsConfig := config.DeepCopy()
+		addonsConfig.Spec.ClusterAddons.ConfigConnectorConfig = &boolTrue
+		status, err := UpdateClusterAddons(ctx, clusterServiceMock, addonsConfig, upstreamSpec)
+		Expect(err).To(MatchError("clusterAddons.configConnectorConfig requires workload identity to be enabled for cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
 })
 
 var _ = Describe("UpdateMasterAuthorizedNetworks", func() {
@@ -504,3 +697,1865 @@ var _ = Describe("UpdateNodePoolAutoscaling", func() {
 	})
 
 })
+
+var _ = Describe("UpdateNetworkPolicyEnabled", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		boolTrue           = true
+		boolFalse          = false
+
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:               "test-region",
+				ProjectID:            "test-project",
+				ClusterName:          "test-cluster",
+				NetworkPolicyEnabled: &boolTrue,
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			ClusterName:          "test-cluster",
+			NetworkPolicyEnabled: &boolFalse,
+		}
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should enable network policy", func() {
+		clusterServiceMock.EXPECT().
+			SetNetworkPolicy(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.SetNetworkPolicyRequest{
+					NetworkPolicy: &gkeapi.NetworkPolicy{
+						Enabled:  true,
+						Provider: NetworkProviderCalico,
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNetworkPolicyEnabled(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not change network policy for autopilot cluster", func() {
+		autopilotConfig := config.DeepCopy()
+		autopilotConfig.Spec.AutopilotConfig = &gkev1.GKEAutopilotConfig{
+			Enabled: true,
+		}
+		status, err := UpdateNetworkPolicyEnabled(ctx, clusterServiceMock, autopilotConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not change network policy for Dataplane V2 cluster", func() {
+		datapathProvider := DatapathProviderAdvanced
+		dataplaneV2UpstreamSpec := upstreamSpec.DeepCopy()
+		dataplaneV2UpstreamSpec.DatapathProvider = &datapathProvider
+		status, err := UpdateNetworkPolicyEnabled(ctx, clusterServiceMock, config, dataplaneV2UpstreamSpec)
+		Expect(err).To(MatchError("field [networkPolicyEnabled] is not supported for Dataplane V2 cluster [test-cluster (id: )], which enforces network policies itself"))
+		Expect(status).To(Equal(NotChanged))
+
+		disabledConfig := config.DeepCopy()
+		disabledConfig.Spec.NetworkPolicyEnabled = &boolFalse
+		status, err = UpdateNetworkPolicyEnabled(ctx, clusterServiceMock, disabledConfig, dataplaneV2UpstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateNodePoolKubernetesVersionOrImageType", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		nodePoolName       = "test-node-pool"
+		k8sVersion         = "1.26.8-gke.110"
+		upstreamVersion    = "1.26.9-gke.100"
+		releaseChannel     = ReleaseChannelRegular
+
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "test-region",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+			},
+		}
+		nodePool = &gkev1.GKENodePoolConfig{
+			Name:    &nodePoolName,
+			Version: &k8sVersion,
+			Config:  &gkev1.GKENodeConfig{},
+		}
+		upstreamNodePool = &gkev1.GKENodePoolConfig{
+			Name:    &nodePoolName,
+			Version: &upstreamVersion,
+			Config:  &gkev1.GKENodeConfig{},
+		}
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should update node pool version", func() {
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(
+				ctx,
+				NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, nodePoolName),
+				&gkeapi.UpdateNodePoolRequest{
+					NodeVersion: k8sVersion,
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolKubernetesVersionOrImageType(ctx, clusterServiceMock, nodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not downgrade a node pool upgraded by the release channel", func() {
+		channelConfig := config.DeepCopy()
+		channelConfig.Spec.ReleaseChannel = &releaseChannel
+
+		status, err := UpdateNodePoolKubernetesVersionOrImageType(ctx, clusterServiceMock, nodePool, channelConfig, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateMaintenancePolicy", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		emptyString        = ""
+
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:            "test-region",
+				ProjectID:         "test-project",
+				ClusterName:       "test-cluster",
+				MaintenanceWindow: &emptyString,
+				MaintenancePolicy: &gkev1.GKEMaintenancePolicy{
+					RecurringWindow: &gkev1.GKERecurringTimeWindow{
+						StartTime:  "2024-01-06T02:00:00Z",
+						EndTime:    "2024-01-06T08:00:00Z",
+						Recurrence: "FREQ=WEEKLY;BYDAY=SA,SU",
+					},
+					MaintenanceExclusions: []gkev1.GKEMaintenanceExclusion{
+						{
+							Name:      "holiday-freeze",
+							StartTime: "2024-12-20T00:00:00Z",
+							EndTime:   "2025-01-05T00:00:00Z",
+							Scope:     MaintenanceExclusionNoMinorUpgrades,
+						},
+					},
+				},
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			ClusterName:       "test-cluster",
+			MaintenanceWindow: &emptyString,
+			MaintenancePolicy: &gkev1.GKEMaintenancePolicy{
+				DailyMaintenanceWindow: &gkev1.GKEDailyMaintenanceWindow{
+					StartTime: "03:00",
+				},
+				ResourceVersion: "abc123",
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should update maintenance policy with the upstream resource version", func() {
+		clusterServiceMock.EXPECT().
+			SetMaintenancePolicy(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.SetMaintenancePolicyRequest{
+					MaintenancePolicy: &gkeapi.MaintenancePolicy{
+						Window: &gkeapi.MaintenanceWindow{
+							RecurringWindow: &gkeapi.RecurringTimeWindow{
+								Recurrence: "FREQ=WEEKLY;BYDAY=SA,SU",
+								Window: &gkeapi.TimeWindow{
+									StartTime: "2024-01-06T02:00:00Z",
+									EndTime:   "2024-01-06T08:00:00Z",
+								},
+							},
+							MaintenanceExclusions: map[string]gkeapi.TimeWindow{
+								"holiday-freeze": {
+									StartTime: "2024-12-20T00:00:00Z",
+									EndTime:   "2025-01-05T00:00:00Z",
+									MaintenanceExclusionOptions: &gkeapi.MaintenanceExclusionOptions{
+										Scope: MaintenanceExclusionNoMinorUpgrades,
+									},
+								},
+							},
+						},
+						ResourceVersion: "abc123",
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateMaintenancePolicy(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update the legacy maintenance window when a maintenance policy is set", func() {
+		status, err := UpdateMaintenanceWindow(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should update the legacy maintenance window", func() {
+		legacyConfig := config.DeepCopy()
+		window := "05:00"
+		legacyConfig.Spec.MaintenanceWindow = &window
+		legacyConfig.Spec.MaintenancePolicy = nil
+
+		clusterServiceMock.EXPECT().
+			SetMaintenancePolicy(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.SetMaintenancePolicyRequest{
+					MaintenancePolicy: &gkeapi.MaintenancePolicy{
+						Window: &gkeapi.MaintenanceWindow{
+							DailyMaintenanceWindow: &gkeapi.DailyMaintenanceWindow{
+								StartTime: "05:00",
+							},
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateMaintenanceWindow(ctx, clusterServiceMock, legacyConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+
+		status, err = UpdateMaintenancePolicy(ctx, clusterServiceMock, legacyConfig, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not update maintenance policy", func() {
+		upstreamSpec.MaintenancePolicy = config.Spec.MaintenancePolicy.DeepCopy()
+		upstreamSpec.MaintenancePolicy.ResourceVersion = "def456"
+		status, err := UpdateMaintenancePolicy(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateNodePoolUpgradeSettings", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		nodePoolName       = "test-node-pool"
+
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "test-region",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+			},
+		}
+		nodePool = &gkev1.GKENodePoolConfig{
+			Name: &nodePoolName,
+			UpgradeSettings: &gkev1.GKENodePoolUpgradeSettings{
+				Strategy: UpgradeStrategyBlueGreen,
+				BlueGreenSettings: &gkev1.GKEBlueGreenSettings{
+					NodePoolSoakDuration: "3600s",
+					StandardRolloutPolicy: &gkev1.GKEStandardRolloutPolicy{
+						BatchNodeCount:    2,
+						BatchSoakDuration: "300s",
+					},
+				},
+			},
+		}
+		upstreamNodePool = &gkev1.GKENodePoolConfig{
+			Name: &nodePoolName,
+			UpgradeSettings: &gkev1.GKENodePoolUpgradeSettings{
+				Strategy: UpgradeStrategySurge,
+				MaxSurge: 1,
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should update node pool to blue-green upgrades", func() {
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(
+				ctx,
+				NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, nodePoolName),
+				&gkeapi.UpdateNodePoolRequest{
+					UpgradeSettings: &gkeapi.UpgradeSettings{
+						Strategy: UpgradeStrategyBlueGreen,
+						BlueGreenSettings: &gkeapi.BlueGreenSettings{
+							NodePoolSoakDuration: "3600s",
+							StandardRolloutPolicy: &gkeapi.StandardRolloutPolicy{
+								BatchNodeCount:    2,
+								BatchSoakDuration: "300s",
+							},
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolUpgradeSettings(ctx, clusterServiceMock, nodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should update node pool surge settings", func() {
+		surgeNodePool := nodePool.DeepCopy()
+		surgeNodePool.UpgradeSettings = &gkev1.GKENodePoolUpgradeSettings{
+			MaxSurge:       0,
+			MaxUnavailable: 1,
+		}
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(
+				ctx,
+				NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, nodePoolName),
+				&gkeapi.UpdateNodePoolRequest{
+					UpgradeSettings: &gkeapi.UpgradeSettings{
+						Strategy:        UpgradeStrategySurge,
+						MaxSurge:        0,
+						MaxUnavailable:  1,
+						ForceSendFields: []string{"MaxSurge", "MaxUnavailable"},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolUpgradeSettings(ctx, clusterServiceMock, surgeNodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should retry updating a busy node pool", func() {
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(
+				ctx,
+				NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, nodePoolName),
+				gomock.Any()).
+			Return(nil, errors.New(errWait))
+
+		status, err := UpdateNodePoolUpgradeSettings(ctx, clusterServiceMock, nodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Retry))
+	})
+
+	It("should not update node pool upgrade settings", func() {
+		status, err := UpdateNodePoolUpgradeSettings(ctx, clusterServiceMock, &gkev1.GKENodePoolConfig{Name: &nodePoolName}, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		// blue-green settings left empty are defaulted by GKE
+		blueGreenNodePool := nodePool.DeepCopy()
+		blueGreenNodePool.UpgradeSettings.BlueGreenSettings.NodePoolSoakDuration = ""
+		upstreamBlueGreenNodePool := nodePool.DeepCopy()
+		upstreamBlueGreenNodePool.UpgradeSettings.MaxSurge = 1
+		status, err = UpdateNodePoolUpgradeSettings(ctx, clusterServiceMock, blueGreenNodePool, config, upstreamBlueGreenNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should fail to update node pool with invalid upgrade settings", func() {
+		invalidNodePool := nodePool.DeepCopy()
+		invalidNodePool.UpgradeSettings.BlueGreenSettings.StandardRolloutPolicy.BatchPercentage = 0.5
+		status, err := UpdateNodePoolUpgradeSettings(ctx, clusterServiceMock, invalidNodePool, config, upstreamNodePool)
+		Expect(err).To(MatchError("only one of batchNodeCount or batchPercentage can be set for node pool [test-node-pool] in cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateNodePoolConfig", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		nodePoolName       = "test-node-pool"
+		nodePoolRRN        string
+
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "test-region",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+			},
+		}
+		nodePool = &gkev1.GKENodePoolConfig{
+			Name: &nodePoolName,
+			Config: &gkev1.GKENodeConfig{
+				Labels: map[string]string{"group": "examples"},
+				Taints: []gkev1.GKENodeTaintConfig{
+					{Effect: "NoSchedule", Key: "group", Value: "examples"},
+					{Effect: "NoExecute", Key: "dedicated", Value: "db"},
+				},
+				Tags:           []string{"red", "blue"},
+				ResourceLabels: map[string]string{"team": "data"},
+			},
+		}
+		upstreamNodePool = &gkev1.GKENodePoolConfig{
+			Name: &nodePoolName,
+			Config: &gkev1.GKENodeConfig{
+				Labels: map[string]string{"group": "examples"},
+				Taints: []gkev1.GKENodeTaintConfig{
+					{Effect: TaintEffectNoExecute, Key: "dedicated", Value: "db"},
+					{Effect: TaintEffectNoSchedule, Key: "group", Value: "examples"},
+				},
+				Tags:           []string{"blue", "red"},
+				ResourceLabels: map[string]string{"team": "data"},
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+		nodePoolRRN = NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, nodePoolName)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should not update node pool config when only the taint effect style differs", func() {
+		status, err := UpdateNodePoolConfig(ctx, clusterServiceMock, nodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should update node pool labels", func() {
+		labelsNodePool := nodePool.DeepCopy()
+		labelsNodePool.Config.Labels["env"] = "test"
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(ctx, nodePoolRRN, &gkeapi.UpdateNodePoolRequest{
+				Labels: &gkeapi.NodeLabels{
+					Labels: map[string]string{"group": "examples", "env": "test"},
+				},
+			}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolConfig(ctx, clusterServiceMock, labelsNodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should update node pool taints", func() {
+		taintsNodePool := nodePool.DeepCopy()
+		taintsNodePool.Config.Taints = []gkev1.GKENodeTaintConfig{
+			{Effect: "PreferNoSchedule", Key: "group", Value: "examples"},
+		}
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(ctx, nodePoolRRN, &gkeapi.UpdateNodePoolRequest{
+				Taints: &gkeapi.NodeTaints{
+					Taints: []*gkeapi.NodeTaint{
+						{Effect: TaintEffectPreferNoSchedule, Key: "group", Value: "examples"},
+					},
+				},
+			}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolConfig(ctx, clusterServiceMock, taintsNodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should remove all node pool taints", func() {
+		taintsNodePool := nodePool.DeepCopy()
+		taintsNodePool.Config.Taints = []gkev1.GKENodeTaintConfig{}
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(ctx, nodePoolRRN, &gkeapi.UpdateNodePoolRequest{
+				Taints: &gkeapi.NodeTaints{
+					Taints: []*gkeapi.NodeTaint{},
+				},
+			}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolConfig(ctx, clusterServiceMock, taintsNodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should update node pool tags", func() {
+		tagsNodePool := nodePool.DeepCopy()
+		tagsNodePool.Config.Tags = []string{"green"}
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(ctx, nodePoolRRN, &gkeapi.UpdateNodePoolRequest{
+				Tags: &gkeapi.NetworkTags{
+					Tags: []string{"green"},
+				},
+			}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolConfig(ctx, clusterServiceMock, tagsNodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should update node pool resource labels", func() {
+		resourceLabelsNodePool := nodePool.DeepCopy()
+		resourceLabelsNodePool.Config.ResourceLabels = map[string]string{}
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(ctx, nodePoolRRN, &gkeapi.UpdateNodePoolRequest{
+				ResourceLabels: &gkeapi.ResourceLabels{
+					Labels: map[string]string{},
+				},
+			}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolConfig(ctx, clusterServiceMock, resourceLabelsNodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should update one node pool config field at a time", func() {
+		changedNodePool := nodePool.DeepCopy()
+		changedNodePool.Config.Tags = []string{"green"}
+		changedNodePool.Config.ResourceLabels = map[string]string{"team": "web"}
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(ctx, nodePoolRRN, &gkeapi.UpdateNodePoolRequest{
+				Tags: &gkeapi.NetworkTags{
+					Tags: []string{"green"},
+				},
+			}).
+			Return(nil, errors.New(errWait))
+
+		status, err := UpdateNodePoolConfig(ctx, clusterServiceMock, changedNodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Retry))
+	})
+
+	It("should not manage node pool config fields that are not set", func() {
+		status, err := UpdateNodePoolConfig(ctx, clusterServiceMock, &gkev1.GKENodePoolConfig{
+			Name:   &nodePoolName,
+			Config: &gkev1.GKENodeConfig{},
+		}, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should update the node pool private nodes override", func() {
+		enablePrivateNodes := true
+		privateNodePool := &gkev1.GKENodePoolConfig{
+			Name: &nodePoolName,
+			NetworkConfig: &gkev1.GKENodeNetworkConfig{
+				EnablePrivateNodes: &enablePrivateNodes,
+			},
+		}
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(ctx, nodePoolRRN, &gkeapi.UpdateNodePoolRequest{
+				NodeNetworkConfig: &gkeapi.NodeNetworkConfig{
+					EnablePrivateNodes: true,
+					ForceSendFields:    []string{"EnablePrivateNodes"},
+				},
+			}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolConfig(ctx, clusterServiceMock, privateNodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+
+		privateUpstreamNodePool := upstreamNodePool.DeepCopy()
+		privateUpstreamNodePool.NetworkConfig = privateNodePool.NetworkConfig.DeepCopy()
+		status, err = UpdateNodePoolConfig(ctx, clusterServiceMock, privateNodePool, config, privateUpstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should switch the node pool to the workload metadata mode of the cluster", func() {
+		workloadIdentityConfig := config.DeepCopy()
+		workloadIdentityConfig.Spec.WorkloadIdentityConfig = &gkev1.GKEWorkloadIdentityConfig{
+			WorkloadPool: "test-project.svc.id.goog",
+		}
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(ctx, nodePoolRRN, &gkeapi.UpdateNodePoolRequest{
+				WorkloadMetadataConfig: &gkeapi.WorkloadMetadataConfig{
+					Mode: WorkloadMetadataModeGKE,
+				},
+			}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolConfig(ctx, clusterServiceMock, nodePool, workloadIdentityConfig, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+
+		gkeMetadataNodePool := upstreamNodePool.DeepCopy()
+		gkeMetadataNodePool.Config.WorkloadMetadataConfig = &gkev1.GKEWorkloadMetadataConfig{
+			Mode: WorkloadMetadataModeGKE,
+		}
+		status, err = UpdateNodePoolConfig(ctx, clusterServiceMock, nodePool, workloadIdentityConfig, gkeMetadataNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		workloadIdentityConfig.Spec.WorkloadIdentityConfig.WorkloadPool = ""
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(ctx, nodePoolRRN, &gkeapi.UpdateNodePoolRequest{
+				WorkloadMetadataConfig: &gkeapi.WorkloadMetadataConfig{
+					Mode: WorkloadMetadataModeGCE,
+				},
+			}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err = UpdateNodePoolConfig(ctx, clusterServiceMock, nodePool, workloadIdentityConfig, gkeMetadataNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+
+		status, err = UpdateNodePoolConfig(ctx, clusterServiceMock, nodePool, workloadIdentityConfig, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should update the workload metadata mode of the node pool", func() {
+		gceMetadataNodePool := nodePool.DeepCopy()
+		gceMetadataNodePool.Config.WorkloadMetadataConfig = &gkev1.GKEWorkloadMetadataConfig{
+			Mode: WorkloadMetadataModeGCE,
+		}
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(ctx, nodePoolRRN, &gkeapi.UpdateNodePoolRequest{
+				WorkloadMetadataConfig: &gkeapi.WorkloadMetadataConfig{
+					Mode: WorkloadMetadataModeGCE,
+				},
+			}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolConfig(ctx, clusterServiceMock, gceMetadataNodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+})
+
+var _ = Describe("UpdateNodePoolMachineConfig", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		nodePoolName       = "test-node-pool"
+
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "test-region",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+			},
+		}
+		nodePool = &gkev1.GKENodePoolConfig{
+			Name: &nodePoolName,
+			Config: &gkev1.GKENodeConfig{
+				MachineType:   "n2-standard-4",
+				DiskSizeGb:    200,
+				DiskType:      "pd-ssd",
+				LocalSsdCount: 1,
+				OauthScopes:   []string{"https://www.googleapis.com/auth/devstorage.read_only", "https://www.googleapis.com/auth/logging.write"},
+			},
+		}
+		upstreamNodePool = &gkev1.GKENodePoolConfig{
+			Name: &nodePoolName,
+			Config: &gkev1.GKENodeConfig{
+				MachineType:    "n1-standard-1",
+				DiskSizeGb:     200,
+				DiskType:       "pd-standard",
+				LocalSsdCount:  1,
+				OauthScopes:    []string{"https://www.googleapis.com/auth/logging.write", "https://www.googleapis.com/auth/devstorage.read_only"},
+				ServiceAccount: "default",
+			},
+		}
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should update node pool machine type and disk type", func() {
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(
+				ctx,
+				NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, nodePoolName),
+				&gkeapi.UpdateNodePoolRequest{
+					MachineType: "n2-standard-4",
+					DiskType:    "pd-ssd",
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolMachineConfig(ctx, clusterServiceMock, nodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should update node pool disk size", func() {
+		diskNodePool := upstreamNodePool.DeepCopy()
+		diskNodePool.Config.DiskSizeGb = 500
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(
+				ctx,
+				NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, nodePoolName),
+				&gkeapi.UpdateNodePoolRequest{
+					DiskSizeGb: 500,
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolMachineConfig(ctx, clusterServiceMock, diskNodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update node pool machine config", func() {
+		status, err := UpdateNodePoolMachineConfig(ctx, clusterServiceMock, upstreamNodePool, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		status, err = UpdateNodePoolMachineConfig(ctx, clusterServiceMock, &gkev1.GKENodePoolConfig{
+			Name:   &nodePoolName,
+			Config: &gkev1.GKENodeConfig{},
+		}, config, upstreamNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should allow node pool updates that GKE applies in place", func() {
+		Expect(ValidateNodePoolUpdate(nodePool, config, upstreamNodePool)).To(Succeed())
+	})
+
+	It("should accept the shielded instance config of node pools created before it was force sent", func() {
+		// The integrity monitoring setting was omitted from the create request when false, so GKE
+		// enabled it on the node pool.
+		createdNodePool := upstreamNodePool.DeepCopy()
+		createdNodePool.Config.ShieldedInstanceConfig = &gkev1.GKEShieldedInstanceConfig{EnableIntegrityMonitoring: true}
+		specNodePool := upstreamNodePool.DeepCopy()
+		specNodePool.Config.ShieldedInstanceConfig = &gkev1.GKEShieldedInstanceConfig{}
+
+		Expect(ValidateNodePoolUpdate(specNodePool, config, createdNodePool)).To(Succeed())
+		status, err := UpdateNodePoolMachineConfig(ctx, clusterServiceMock, specNodePool, config, createdNodePool)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		specNodePool.Config.ShieldedInstanceConfig.EnableSecureBoot = true
+		Expect(ValidateNodePoolUpdate(specNodePool, config, createdNodePool)).To(MatchError("field [shieldedInstanceConfig] of node pool [test-node-pool] in cluster [test-cluster (id: )] cannot be updated in place, set replacementPolicy to Replace to replace the node pool"))
+	})
+
+	It("should reject node pool updates that require recreating the node pool", func() {
+		invalidNodePool := nodePool.DeepCopy()
+		invalidNodePool.Config.LocalSsdCount = 2
+		Expect(ValidateNodePoolUpdate(invalidNodePool, config, upstreamNodePool)).To(MatchError("field [localSsdCount] of node pool [test-node-pool] in cluster [test-cluster (id: )] cannot be updated in place, set replacementPolicy to Replace to replace the node pool"))
+
+		invalidNodePool = nodePool.DeepCopy()
+		invalidNodePool.Config.Preemptible = true
+		Expect(ValidateNodePoolUpdate(invalidNodePool, config, upstreamNodePool)).To(MatchError("field [preemptible] of node pool [test-node-pool] in cluster [test-cluster (id: )] cannot be updated in place, set replacementPolicy to Replace to replace the node pool"))
+
+		invalidNodePool = nodePool.DeepCopy()
+		invalidNodePool.Config.Spot = true
+		Expect(ValidateNodePoolUpdate(invalidNodePool, config, upstreamNodePool)).To(MatchError("field [spot] of node pool [test-node-pool] in cluster [test-cluster (id: )] cannot be updated in place, set replacementPolicy to Replace to replace the node pool"))
+
+		invalidNodePool = nodePool.DeepCopy()
+		invalidNodePool.Config.Accelerators = []gkev1.GKEAcceleratorConfig{
+			{AcceleratorCount: 1, AcceleratorType: "nvidia-tesla-t4"},
+		}
+		Expect(ValidateNodePoolUpdate(invalidNodePool, config, upstreamNodePool)).To(MatchError("field [accelerators] of node pool [test-node-pool] in cluster [test-cluster (id: )] cannot be updated in place, set replacementPolicy to Replace to replace the node pool"))
+
+		invalidNodePool = nodePool.DeepCopy()
+		invalidNodePool.Config.ServiceAccount = "nodes@test-project.iam.gserviceaccount.com"
+		Expect(ValidateNodePoolUpdate(invalidNodePool, config, upstreamNodePool)).To(MatchError("field [serviceAccount] of node pool [test-node-pool] in cluster [test-cluster (id: )] cannot be updated in place, set replacementPolicy to Replace to replace the node pool"))
+
+		invalidNodePool = nodePool.DeepCopy()
+		invalidNodePool.Config.OauthScopes = []string{"https://www.googleapis.com/auth/cloud-platform"}
+		Expect(ValidateNodePoolUpdate(invalidNodePool, config, upstreamNodePool)).To(MatchError("field [oauthScopes] of node pool [test-node-pool] in cluster [test-cluster (id: )] cannot be updated in place, set replacementPolicy to Replace to replace the node pool"))
+
+		cmekConfig := config.DeepCopy()
+		cmekConfig.Spec.CustomerManagedEncryptionKey = &gkev1.CMEKConfig{
+			RingName: "test-keyring",
+			KeyName:  "test-key",
+		}
+		Expect(ValidateNodePoolUpdate(nodePool, cmekConfig, upstreamNodePool)).To(MatchError("field [bootDiskKmsKey] of node pool [test-node-pool] in cluster [test-cluster (id: )] cannot be updated in place, set replacementPolicy to Replace to replace the node pool"))
+
+		invalidNodePool = nodePool.DeepCopy()
+		invalidNodePool.NetworkConfig = &gkev1.GKENodeNetworkConfig{PodRange: "pods-2"}
+		Expect(ValidateNodePoolUpdate(invalidNodePool, config, upstreamNodePool)).To(MatchError("field [networkConfig.podRange] of node pool [test-node-pool] in cluster [test-cluster (id: )] cannot be updated in place, set replacementPolicy to Replace to replace the node pool"))
+
+		invalidNodePool = nodePool.DeepCopy()
+		invalidNodePool.Config.ShieldedInstanceConfig = &gkev1.GKEShieldedInstanceConfig{EnableSecureBoot: true}
+		Expect(ValidateNodePoolUpdate(invalidNodePool, config, upstreamNodePool)).To(MatchError("field [shieldedInstanceConfig] of node pool [test-node-pool] in cluster [test-cluster (id: )] cannot be updated in place, set replacementPolicy to Replace to replace the node pool"))
+	})
+
+	It("should get a node pool", func() {
+		clusterServiceMock.EXPECT().
+			NodePoolGet(
+				ctx,
+				NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, nodePoolName)).
+			Return(&gkeapi.NodePool{Name: nodePoolName, Status: "RUNNING"}, nil)
+
+		np, err := GetNodePool(ctx, clusterServiceMock, &config.Spec, nodePoolName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(np.Status).To(Equal("RUNNING"))
+	})
+
+	It("should return nil if the node pool doesn't exist", func() {
+		clusterServiceMock.EXPECT().
+			NodePoolGet(
+				ctx,
+				NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, "missing-node-pool")).
+			Return(nil, errors.New("googleapi: Error 404: Not found: missing-node-pool., notFound"))
+
+		np, err := GetNodePool(ctx, clusterServiceMock, &config.Spec, "missing-node-pool")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(np).To(BeNil())
+	})
+})
+
+var _ = Describe("UpdateLocations", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		config             *gkev1.GKEClusterConfig
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "us-east1",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+				Locations:   []string{"us-east1-c", "us-east1-b"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should update the locations of a regional cluster", func() {
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredLocations: []string{"us-east1-b", "us-east1-c"},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateLocations(ctx, clusterServiceMock, config, &gkev1.GKEClusterConfigSpec{
+			Locations: []string{"us-east1-b", "us-east1-c", "us-east1-d"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update the locations of a regional cluster when none are specified", func() {
+		config.Spec.Locations = []string{}
+		status, err := UpdateLocations(ctx, clusterServiceMock, config, &gkev1.GKEClusterConfigSpec{
+			Locations: []string{"us-east1-b", "us-east1-c", "us-east1-d"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not update the inferred location of a zonal cluster", func() {
+		config.Spec.Region = ""
+		config.Spec.Zone = "us-east1-b"
+		config.Spec.Locations = []string{}
+		status, err := UpdateLocations(ctx, clusterServiceMock, config, &gkev1.GKEClusterConfigSpec{
+			Locations: []string{"us-east1-b"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateNodePoolLocations", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		nodePoolName       = "test-node-pool"
+		config             = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "us-east1",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+			},
+		}
+		nodePool = &gkev1.GKENodePoolConfig{
+			Name:      &nodePoolName,
+			Locations: []string{"us-east1-c", "us-east1-b"},
+		}
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should update node pool locations", func() {
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(
+				ctx,
+				NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, nodePoolName),
+				&gkeapi.UpdateNodePoolRequest{
+					Locations: []string{"us-east1-c", "us-east1-b"},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateNodePoolLocations(ctx, clusterServiceMock, nodePool, config, &gkev1.GKENodePoolConfig{
+			Name:      &nodePoolName,
+			Locations: []string{"us-east1-b", "us-east1-c", "us-east1-d"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should retry updating the locations of a busy node pool", func() {
+		clusterServiceMock.EXPECT().
+			NodePoolUpdate(
+				ctx,
+				NodePoolRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName, nodePoolName),
+				gomock.Any()).
+			Return(nil, errors.New(errWait))
+
+		status, err := UpdateNodePoolLocations(ctx, clusterServiceMock, nodePool, config, &gkev1.GKENodePoolConfig{
+			Name: &nodePoolName,
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Retry))
+	})
+
+	It("should not update node pool locations", func() {
+		status, err := UpdateNodePoolLocations(ctx, clusterServiceMock, nodePool, config, &gkev1.GKENodePoolConfig{
+			Name:      &nodePoolName,
+			Locations: []string{"us-east1-b", "us-east1-c"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		status, err = UpdateNodePoolLocations(ctx, clusterServiceMock, &gkev1.GKENodePoolConfig{Name: &nodePoolName}, config, &gkev1.GKENodePoolConfig{
+			Name:      &nodePoolName,
+			Locations: []string{"us-east1-b"},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateClusterAutoscaling", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		config             *gkev1.GKEClusterConfig
+		upstreamSpec       *gkev1.GKEClusterConfigSpec
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "us-east1",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+				ClusterAutoscaling: &gkev1.GKEClusterAutoscaling{
+					EnableNodeAutoprovisioning: true,
+					ResourceLimits: []gkev1.GKEResourceLimit{
+						{ResourceType: ResourceLimitCPU, Maximum: 32},
+						{ResourceType: ResourceLimitMemory, Maximum: 128},
+					},
+				},
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			ClusterAutoscaling: &gkev1.GKEClusterAutoscaling{
+				AutoscalingProfile: AutoscalingProfileBalanced,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should enable node auto-provisioning", func() {
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredClusterAutoscaling: &gkeapi.ClusterAutoscaling{
+							EnableNodeAutoprovisioning: true,
+							ResourceLimits: []*gkeapi.ResourceLimit{
+								{ResourceType: ResourceLimitCPU, Maximum: 32},
+								{ResourceType: ResourceLimitMemory, Maximum: 128},
+							},
+							ForceSendFields: []string{"EnableNodeAutoprovisioning"},
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateClusterAutoscaling(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update cluster autoscaling", func() {
+		upstreamSpec.ClusterAutoscaling = config.Spec.ClusterAutoscaling.DeepCopy()
+		status, err := UpdateClusterAutoscaling(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.ClusterAutoscaling = nil
+		status, err = UpdateClusterAutoscaling(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should fail to update invalid cluster autoscaling", func() {
+		config.Spec.ClusterAutoscaling.ResourceLimits = nil
+		status, err := UpdateClusterAutoscaling(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError("node auto-provisioning requires cpu and memory resource limits for cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateDNSConfig", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		config             *gkev1.GKEClusterConfig
+		upstreamSpec       *gkev1.GKEClusterConfigSpec
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "us-east1",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+				DNSConfig: &gkev1.GKEDNSConfig{
+					ClusterDNS:      ClusterDNSCloudDNS,
+					ClusterDNSScope: ClusterDNSScopeCluster,
+				},
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			DNSConfig: &gkev1.GKEDNSConfig{},
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should move the cluster to Cloud DNS", func() {
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredDnsConfig: &gkeapi.DNSConfig{
+							ClusterDns:      ClusterDNSCloudDNS,
+							ClusterDnsScope: ClusterDNSScopeCluster,
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateDNSConfig(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update the DNS config", func() {
+		upstreamSpec.DNSConfig = config.Spec.DNSConfig.DeepCopy()
+		status, err := UpdateDNSConfig(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.DNSConfig = nil
+		status, err = UpdateDNSConfig(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should fail to update an invalid DNS config", func() {
+		config.Spec.DNSConfig.ClusterDNS = ClusterDNSKubeDNS
+		status, err := UpdateDNSConfig(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError("clusterDnsScope requires the CLOUD_DNS provider for cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateStackType", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		config             *gkev1.GKEClusterConfig
+		upstreamSpec       *gkev1.GKEClusterConfigSpec
+		datapathProvider   = DatapathProviderAdvanced
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "us-east1",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+				IPAllocationPolicy: &gkev1.GKEIPAllocationPolicy{
+					UseIPAliases: true,
+					StackType:    StackTypeIPv4IPv6,
+				},
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			DatapathProvider: &datapathProvider,
+			IPAllocationPolicy: &gkev1.GKEIPAllocationPolicy{
+				UseIPAliases: true,
+				StackType:    StackTypeIPv4,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should update the stack type to dual-stack", func() {
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredStackType: StackTypeIPv4IPv6,
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateStackType(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update the stack type", func() {
+		upstreamSpec.IPAllocationPolicy.StackType = StackTypeIPv4IPv6
+		status, err := UpdateStackType(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.IPAllocationPolicy.StackType = ""
+		status, err = UpdateStackType(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not update the stack type to dual-stack without Dataplane V2", func() {
+		upstreamSpec.DatapathProvider = nil
+		status, err := UpdateStackType(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError("the IPV4_IPV6 stack type requires the ADVANCED_DATAPATH datapath provider for cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.IPAllocationPolicy.UseIPAliases = false
+		status, err = UpdateStackType(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError("the IPV4_IPV6 stack type requires a VPC-native cluster with useIpAliases for cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateAdditionalPodRanges", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		config             *gkev1.GKEClusterConfig
+		upstreamSpec       *gkev1.GKEClusterConfigSpec
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "us-east1",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+				IPAllocationPolicy: &gkev1.GKEIPAllocationPolicy{
+					UseIPAliases:              true,
+					ClusterSecondaryRangeName: "pods",
+					AdditionalPodRangesConfig: &gkev1.GKEAdditionalPodRangesConfig{
+						PodRangeNames: []string{"pods-2", "pods-3"},
+					},
+				},
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			IPAllocationPolicy: &gkev1.GKEIPAllocationPolicy{
+				UseIPAliases:              true,
+				ClusterSecondaryRangeName: "pods",
+				AdditionalPodRangesConfig: &gkev1.GKEAdditionalPodRangesConfig{
+					PodRangeNames: []string{"pods-2"},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should add additional pod ranges", func() {
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						AdditionalPodRangesConfig: &gkeapi.AdditionalPodRangesConfig{
+							PodRangeNames: []string{"pods-3"},
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateAdditionalPodRanges(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should remove additional pod ranges", func() {
+		config.Spec.IPAllocationPolicy.AdditionalPodRangesConfig.PodRangeNames = []string{}
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						RemovedAdditionalPodRangesConfig: &gkeapi.AdditionalPodRangesConfig{
+							PodRangeNames: []string{"pods-2"},
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateAdditionalPodRanges(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update additional pod ranges", func() {
+		config.Spec.IPAllocationPolicy.AdditionalPodRangesConfig.PodRangeNames = []string{"pods-2"}
+		status, err := UpdateAdditionalPodRanges(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.IPAllocationPolicy.AdditionalPodRangesConfig = nil
+		status, err = UpdateAdditionalPodRanges(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not add a pod range that conflicts with the cluster secondary range", func() {
+		config.Spec.IPAllocationPolicy.AdditionalPodRangesConfig.PodRangeNames = []string{"pods-2", "pods"}
+		status, err := UpdateAdditionalPodRanges(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError("pod range conflict: additional pod range [pods] is already used as the cluster or services secondary range for cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdatePrivateClusterConfig", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		config             *gkev1.GKEClusterConfig
+		upstreamSpec       *gkev1.GKEClusterConfigSpec
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "us-east1",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+				PrivateClusterConfig: &gkev1.GKEPrivateClusterConfig{
+					EnablePrivateEndpoint: true,
+					EnablePrivateNodes:    true,
+					MasterIpv4CidrBlock:   "172.16.0.0/28",
+					MasterGlobalAccessConfig: &gkev1.GKEMasterGlobalAccessConfig{
+						Enabled: true,
+					},
+				},
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			PrivateClusterConfig: &gkev1.GKEPrivateClusterConfig{
+				EnablePrivateEndpoint: false,
+				EnablePrivateNodes:    true,
+				MasterIpv4CidrBlock:   "172.16.0.0/28",
+				MasterGlobalAccessConfig: &gkev1.GKEMasterGlobalAccessConfig{
+					Enabled: false,
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should update the private endpoint first", func() {
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredEnablePrivateEndpoint: true,
+						ForceSendFields:              []string{"DesiredEnablePrivateEndpoint"},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdatePrivateClusterConfig(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should update the master global access", func() {
+		upstreamSpec.PrivateClusterConfig.EnablePrivateEndpoint = true
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredPrivateClusterConfig: &gkeapi.PrivateClusterConfig{
+							MasterGlobalAccessConfig: &gkeapi.PrivateClusterMasterGlobalAccessConfig{
+								Enabled:         true,
+								ForceSendFields: []string{"Enabled"},
+							},
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdatePrivateClusterConfig(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update the private cluster config", func() {
+		upstreamSpec.PrivateClusterConfig = config.Spec.PrivateClusterConfig.DeepCopy()
+		status, err := UpdatePrivateClusterConfig(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.PrivateClusterConfig.MasterGlobalAccessConfig = nil
+		upstreamSpec.PrivateClusterConfig.MasterGlobalAccessConfig.Enabled = false
+		status, err = UpdatePrivateClusterConfig(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not enable the private endpoint without private nodes", func() {
+		config.Spec.PrivateClusterConfig.EnablePrivateNodes = false
+		upstreamSpec.PrivateClusterConfig.EnablePrivateNodes = false
+		status, err := UpdatePrivateClusterConfig(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError("private endpoint requires private nodes for cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateControlPlaneEndpoints", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		config             *gkev1.GKEClusterConfig
+		upstreamSpec       *gkev1.GKEClusterConfigSpec
+		boolTrue           = true
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "us-east1",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+				ControlPlaneEndpointsConfig: &gkev1.GKEControlPlaneEndpointsConfig{
+					DNSEndpointConfig: &gkev1.GKEDNSEndpointConfig{
+						AllowExternalTraffic: true,
+					},
+				},
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			ControlPlaneEndpointsConfig: &gkev1.GKEControlPlaneEndpointsConfig{
+				DNSEndpointConfig: &gkev1.GKEDNSEndpointConfig{
+					AllowExternalTraffic: false,
+				},
+				IPEndpointsConfig: &gkev1.GKEIPEndpointsConfig{
+					Enabled: &boolTrue,
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should enable the DNS endpoint", func() {
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredControlPlaneEndpointsConfig: &gkeapi.ControlPlaneEndpointsConfig{
+							DnsEndpointConfig: &gkeapi.DNSEndpointConfig{
+								AllowExternalTraffic: true,
+								ForceSendFields:      []string{"AllowExternalTraffic"},
+							},
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateControlPlaneEndpoints(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update the control plane endpoints", func() {
+		upstreamSpec.ControlPlaneEndpointsConfig.DNSEndpointConfig.AllowExternalTraffic = true
+		status, err := UpdateControlPlaneEndpoints(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.ControlPlaneEndpointsConfig = nil
+		status, err = UpdateControlPlaneEndpoints(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not disable the IP endpoints without the DNS endpoint", func() {
+		boolFalse := false
+		config.Spec.ControlPlaneEndpointsConfig.DNSEndpointConfig.AllowExternalTraffic = false
+		config.Spec.ControlPlaneEndpointsConfig.IPEndpointsConfig = &gkev1.GKEIPEndpointsConfig{
+			Enabled: &boolFalse,
+		}
+		status, err := UpdateControlPlaneEndpoints(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError("disabling the IP endpoints requires dnsEndpointConfig.allowExternalTraffic to reach the control plane of cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateDatabaseEncryption", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		config             *gkev1.GKEClusterConfig
+		upstreamSpec       *gkev1.GKEClusterConfigSpec
+		keyName            = "projects/test-project/locations/us-east1/keyRings/test-keyring/cryptoKeys/test-key"
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "us-east1",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+				DatabaseEncryption: &gkev1.GKEDatabaseEncryption{
+					State:   DatabaseEncryptionEncrypted,
+					KeyName: keyName,
+				},
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			DatabaseEncryption: &gkev1.GKEDatabaseEncryption{
+				State:        DatabaseEncryptionDecrypted,
+				CurrentState: "CURRENT_STATE_DECRYPTED",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should encrypt the database", func() {
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredDatabaseEncryption: &gkeapi.DatabaseEncryption{
+							State:   DatabaseEncryptionEncrypted,
+							KeyName: keyName,
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateDatabaseEncryption(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should rotate the database encryption key", func() {
+		upstreamSpec.DatabaseEncryption = &gkev1.GKEDatabaseEncryption{
+			State:        DatabaseEncryptionEncrypted,
+			KeyName:      "projects/test-project/locations/us-east1/keyRings/test-keyring/cryptoKeys/old-key",
+			CurrentState: "CURRENT_STATE_ENCRYPTED",
+		}
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredDatabaseEncryption: &gkeapi.DatabaseEncryption{
+							State:   DatabaseEncryptionEncrypted,
+							KeyName: keyName,
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateDatabaseEncryption(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update the database encryption", func() {
+		upstreamSpec.DatabaseEncryption = config.Spec.DatabaseEncryption.DeepCopy()
+		upstreamSpec.DatabaseEncryption.CurrentState = "CURRENT_STATE_ENCRYPTED"
+		status, err := UpdateDatabaseEncryption(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.DatabaseEncryption = &gkev1.GKEDatabaseEncryption{
+			State: DatabaseEncryptionDecrypted,
+		}
+		upstreamSpec.DatabaseEncryption = &gkev1.GKEDatabaseEncryption{
+			State:        DatabaseEncryptionDecrypted,
+			CurrentState: "CURRENT_STATE_DECRYPTED",
+		}
+		status, err = UpdateDatabaseEncryption(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should wait while the database is being encrypted", func() {
+		upstreamSpec.DatabaseEncryption.CurrentState = "CURRENT_STATE_ENCRYPTION_PENDING"
+		status, err := UpdateDatabaseEncryption(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Retry))
+
+		config.Spec.DatabaseEncryption = nil
+		status, err = UpdateDatabaseEncryption(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Retry))
+	})
+
+	It("should not encrypt the database without a key", func() {
+		config.Spec.DatabaseEncryption.KeyName = ""
+		status, err := UpdateDatabaseEncryption(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError("keyName is required to encrypt the database of cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateWorkloadIdentity", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		config             *gkev1.GKEClusterConfig
+		upstreamSpec       *gkev1.GKEClusterConfigSpec
+		nodePoolName       = "test-node-pool"
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "us-east1",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+				WorkloadIdentityConfig: &gkev1.GKEWorkloadIdentityConfig{
+					WorkloadPool: "test-project.svc.id.goog",
+				},
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			WorkloadIdentityConfig: &gkev1.GKEWorkloadIdentityConfig{},
+			NodePools: []gkev1.GKENodePoolConfig{
+				{
+					Name: &nodePoolName,
+					Config: &gkev1.GKENodeConfig{
+						WorkloadMetadataConfig: &gkev1.GKEWorkloadMetadataConfig{
+							Mode: WorkloadMetadataModeGCE,
+						},
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should enable workload identity", func() {
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredWorkloadIdentityConfig: &gkeapi.WorkloadIdentityConfig{
+							WorkloadPool: "test-project.svc.id.goog",
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateWorkloadIdentity(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should disable workload identity once the node pools stopped using the GKE metadata server", func() {
+		config.Spec.WorkloadIdentityConfig.WorkloadPool = ""
+		upstreamSpec.WorkloadIdentityConfig.WorkloadPool = "test-project.svc.id.goog"
+		upstreamSpec.NodePools[0].Config.WorkloadMetadataConfig.Mode = WorkloadMetadataModeGKE
+		status, err := UpdateWorkloadIdentity(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		upstreamSpec.NodePools[0].Config.WorkloadMetadataConfig.Mode = WorkloadMetadataModeGCE
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredWorkloadIdentityConfig: &gkeapi.WorkloadIdentityConfig{},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err = UpdateWorkloadIdentity(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update workload identity", func() {
+		upstreamSpec.WorkloadIdentityConfig.WorkloadPool = "test-project.svc.id.goog"
+		status, err := UpdateWorkloadIdentity(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.WorkloadIdentityConfig = nil
+		status, err = UpdateWorkloadIdentity(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not update workload identity with an invalid configuration", func() {
+		config.Spec.WorkloadIdentityConfig.WorkloadPool = "other-project.svc.id.goog"
+		status, err := UpdateWorkloadIdentity(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError(`invalid workload pool "other-project.svc.id.goog" for cluster [test-cluster (id: )], must be test-project.svc.id.goog`))
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.WorkloadIdentityConfig.WorkloadPool = ""
+		upstreamSpec.WorkloadIdentityConfig.WorkloadPool = "test-project.svc.id.goog"
+		config.Spec.NodePools = []gkev1.GKENodePoolConfig{
+			{
+				Name: &nodePoolName,
+				Config: &gkev1.GKENodeConfig{
+					WorkloadMetadataConfig: &gkev1.GKEWorkloadMetadataConfig{
+						Mode: WorkloadMetadataModeGKE,
+					},
+				},
+			},
+		}
+		status, err = UpdateWorkloadIdentity(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError("workload metadata mode GKE_METADATA of node pool [test-node-pool] requires workload identity to be enabled for cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.NodePools = nil
+		boolTrue := true
+		config.Spec.ClusterAddons = &gkev1.GKEClusterAddons{ConfigConnectorConfig: &boolTrue}
+		status, err = UpdateWorkloadIdentity(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError("clusterAddons.configConnectorConfig requires workload identity to be enabled for cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+})
+
+var _ = Describe("UpdateShieldedNodes", func() {
+	var (
+		mockController     *gomock.Controller
+		clusterServiceMock *mock_services.MockGKEClusterService
+		config             *gkev1.GKEClusterConfig
+		upstreamSpec       *gkev1.GKEClusterConfigSpec
+	)
+
+	BeforeEach(func() {
+		mockController = gomock.NewController(GinkgoT())
+		clusterServiceMock = mock_services.NewMockGKEClusterService(mockController)
+		config = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				Region:      "us-east1",
+				ProjectID:   "test-project",
+				ClusterName: "test-cluster",
+				ShieldedNodes: &gkev1.GKEShieldedNodes{
+					Enabled: true,
+				},
+			},
+		}
+		upstreamSpec = &gkev1.GKEClusterConfigSpec{
+			ShieldedNodes: &gkev1.GKEShieldedNodes{},
+		}
+	})
+
+	AfterEach(func() {
+		mockController.Finish()
+	})
+
+	It("should enable shielded nodes", func() {
+		config.Status.AppliedShieldedNodes = &gkev1.GKEShieldedNodes{}
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredShieldedNodes: &gkeapi.ShieldedNodes{
+							Enabled:         true,
+							ForceSendFields: []string{"Enabled"},
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateShieldedNodes(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should disable shielded nodes", func() {
+		config.Spec.ShieldedNodes.Enabled = false
+		config.Status.AppliedShieldedNodes = &gkev1.GKEShieldedNodes{Enabled: true}
+		upstreamSpec.ShieldedNodes.Enabled = true
+		clusterServiceMock.EXPECT().
+			ClusterUpdate(
+				ctx,
+				ClusterRRN(config.Spec.ProjectID, Location(config.Spec.Region, config.Spec.Zone), config.Spec.ClusterName),
+				&gkeapi.UpdateClusterRequest{
+					Update: &gkeapi.ClusterUpdate{
+						DesiredShieldedNodes: &gkeapi.ShieldedNodes{
+							Enabled:         false,
+							ForceSendFields: []string{"Enabled"},
+						},
+					},
+				}).
+			Return(&gkeapi.Operation{}, nil)
+
+		status, err := UpdateShieldedNodes(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(Changed))
+	})
+
+	It("should not update shielded nodes", func() {
+		upstreamSpec.ShieldedNodes.Enabled = true
+		status, err := UpdateShieldedNodes(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		config.Spec.ShieldedNodes = nil
+		upstreamSpec.ShieldedNodes.Enabled = false
+		status, err = UpdateShieldedNodes(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not disable shielded nodes of clusters created before the field was sent", func() {
+		// the disabled setting was omitted from the create request, so GKE enabled shielded nodes
+		config.Spec.ShieldedNodes.Enabled = false
+		upstreamSpec.ShieldedNodes.Enabled = true
+		status, err := UpdateShieldedNodes(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+
+		config.Status.AppliedShieldedNodes = &gkev1.GKEShieldedNodes{}
+		status, err = UpdateShieldedNodes(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should not disable shielded nodes for autopilot clusters", func() {
+		config.Spec.ShieldedNodes.Enabled = false
+		config.Status.AppliedShieldedNodes = &gkev1.GKEShieldedNodes{Enabled: true}
+		config.Spec.AutopilotConfig = &gkev1.GKEAutopilotConfig{Enabled: true}
+		upstreamSpec.ShieldedNodes.Enabled = true
+		status, err := UpdateShieldedNodes(ctx, clusterServiceMock, config, upstreamSpec)
+		Expect(err).To(MatchError("field [shieldedNodes] is not supported for autopilot cluster [test-cluster (id: )]"))
+		Expect(status).To(Equal(NotChanged))
+	})
+
+	It("should round-trip the shielded instance config of a node pool", func() {
+		shieldedInstanceConfig := &gkev1.GKEShieldedInstanceConfig{EnableSecureBoot: true}
+		Expect(NewShieldedInstanceConfig(shieldedInstanceConfig)).To(Equal(&gkeapi.ShieldedInstanceConfig{
+			EnableSecureBoot: true,
+			ForceSendFields:  []string{"EnableIntegrityMonitoring", "EnableSecureBoot"},
+		}))
+		Expect(BuildShieldedInstanceConfig(NewShieldedInstanceConfig(shieldedInstanceConfig))).To(Equal(shieldedInstanceConfig))
+		Expect(BuildShieldedInstanceConfig(nil)).To(BeNil())
+		Expect(BuildShieldedNodes(nil)).To(Equal(&gkev1.GKEShieldedNodes{}))
+	})
+})
@@ -0,0 +1,148 @@
+package gke
+
+import (
+	"fmt"
+	"reflect"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/utils"
+)
+
+// Node Pool Upgrade Strategies
+const (
+	// UpgradeStrategySurge upgrades nodes in a rolling fashion, creating up to maxSurge new nodes
+	// while at most maxUnavailable nodes are unavailable. This is the default strategy.
+	UpgradeStrategySurge = "SURGE"
+	// UpgradeStrategyBlueGreen creates a new set of nodes and drains the old ones in batches
+	UpgradeStrategyBlueGreen = "BLUE_GREEN"
+)
+
+// newUpgradeSettings returns the GKE upgrade settings for the given spec. The surge settings are
+// always sent so that maxSurge or maxUnavailable can be set to 0.
+func newUpgradeSettings(settings *gkev1.GKENodePoolUpgradeSettings) *gkeapi.UpgradeSettings {
+	if settings == nil {
+		return nil
+	}
+	if settings.Strategy == UpgradeStrategyBlueGreen {
+		upgradeSettings := &gkeapi.UpgradeSettings{
+			Strategy: UpgradeStrategyBlueGreen,
+		}
+		if blueGreen := settings.BlueGreenSettings; blueGreen != nil {
+			upgradeSettings.BlueGreenSettings = &gkeapi.BlueGreenSettings{
+				NodePoolSoakDuration: blueGreen.NodePoolSoakDuration,
+			}
+			if policy := blueGreen.StandardRolloutPolicy; policy != nil {
+				upgradeSettings.BlueGreenSettings.StandardRolloutPolicy = &gkeapi.StandardRolloutPolicy{
+					BatchNodeCount:    policy.BatchNodeCount,
+					BatchPercentage:   policy.BatchPercentage,
+					BatchSoakDuration: policy.BatchSoakDuration,
+				}
+			}
+		}
+		return upgradeSettings
+	}
+	return &gkeapi.UpgradeSettings{
+		Strategy:        UpgradeStrategySurge,
+		MaxSurge:        settings.MaxSurge,
+		MaxUnavailable:  settings.MaxUnavailable,
+		ForceSendFields: []string{"MaxSurge", "MaxUnavailable"},
+	}
+}
+
+// BuildUpgradeSettings returns the spec representation of the given GKE upgrade settings.
+func BuildUpgradeSettings(settings *gkeapi.UpgradeSettings) *gkev1.GKENodePoolUpgradeSettings {
+	if settings == nil {
+		return nil
+	}
+	upgradeSettings := &gkev1.GKENodePoolUpgradeSettings{
+		Strategy:       settings.Strategy,
+		MaxSurge:       settings.MaxSurge,
+		MaxUnavailable: settings.MaxUnavailable,
+	}
+	if blueGreen := settings.BlueGreenSettings; blueGreen != nil {
+		upgradeSettings.BlueGreenSettings = &gkev1.GKEBlueGreenSettings{
+			NodePoolSoakDuration: blueGreen.NodePoolSoakDuration,
+		}
+		if policy := blueGreen.StandardRolloutPolicy; policy != nil {
+			upgradeSettings.BlueGreenSettings.StandardRolloutPolicy = &gkev1.GKEStandardRolloutPolicy{
+				BatchNodeCount:    policy.BatchNodeCount,
+				BatchPercentage:   policy.BatchPercentage,
+				BatchSoakDuration: policy.BatchSoakDuration,
+			}
+		}
+	}
+	return upgradeSettings
+}
+
+// upgradeSettingsEqual returns true if the upstream upgrade settings match the spec. Only the
+// settings used by the strategy are compared, and blue-green settings left empty in the spec
+// are defaulted by GKE, so they are not compared.
+func upgradeSettingsEqual(settings, upstream *gkev1.GKENodePoolUpgradeSettings) bool {
+	if upstream == nil {
+		upstream = &gkev1.GKENodePoolUpgradeSettings{}
+	}
+	if upgradeStrategy(settings) != upgradeStrategy(upstream) {
+		return false
+	}
+	if upgradeStrategy(settings) == UpgradeStrategySurge {
+		return settings.MaxSurge == upstream.MaxSurge && settings.MaxUnavailable == upstream.MaxUnavailable
+	}
+
+	blueGreen := settings.BlueGreenSettings
+	if blueGreen == nil {
+		return true
+	}
+	upstreamBlueGreen := upstream.BlueGreenSettings
+	if upstreamBlueGreen == nil {
+		upstreamBlueGreen = &gkev1.GKEBlueGreenSettings{}
+	}
+	if blueGreen.NodePoolSoakDuration != "" && blueGreen.NodePoolSoakDuration != upstreamBlueGreen.NodePoolSoakDuration {
+		return false
+	}
+	return blueGreen.StandardRolloutPolicy == nil ||
+		reflect.DeepEqual(blueGreen.StandardRolloutPolicy, upstreamBlueGreen.StandardRolloutPolicy)
+}
+
+func upgradeStrategy(settings *gkev1.GKENodePoolUpgradeSettings) string {
+	if settings.Strategy == "" {
+		return UpgradeStrategySurge
+	}
+	return settings.Strategy
+}
+
+func validateUpgradeSettings(np *gkev1.GKENodePoolConfig, config *gkev1.GKEClusterConfig) error {
+	settings := np.UpgradeSettings
+	if settings == nil {
+		return nil
+	}
+	npName := utils.StringValue(np.Name)
+	clusterName := config.Spec.ClusterName
+	switch settings.Strategy {
+	case "", UpgradeStrategySurge:
+		if settings.BlueGreenSettings != nil {
+			return fmt.Errorf("blueGreenSettings can only be set with the %s upgrade strategy for node pool [%s] in cluster [%s (id: %s)]", UpgradeStrategyBlueGreen, npName, clusterName, config.Name)
+		}
+		if settings.MaxSurge < 0 || settings.MaxUnavailable < 0 {
+			return fmt.Errorf("maxSurge and maxUnavailable must be >= 0 for node pool [%s] in cluster [%s (id: %s)]", npName, clusterName, config.Name)
+		}
+		if settings.MaxSurge == 0 && settings.MaxUnavailable == 0 {
+			return fmt.Errorf("maxSurge and maxUnavailable cannot both be 0 for node pool [%s] in cluster [%s (id: %s)]", npName, clusterName, config.Name)
+		}
+	case UpgradeStrategyBlueGreen:
+		if settings.BlueGreenSettings == nil || settings.BlueGreenSettings.StandardRolloutPolicy == nil {
+			return nil
+		}
+		policy := settings.BlueGreenSettings.StandardRolloutPolicy
+		if policy.BatchNodeCount != 0 && policy.BatchPercentage != 0 {
+			return fmt.Errorf("only one of batchNodeCount or batchPercentage can be set for node pool [%s] in cluster [%s (id: %s)]", npName, clusterName, config.Name)
+		}
+		if policy.BatchNodeCount < 0 || policy.BatchPercentage < 0 || policy.BatchPercentage > 1 {
+			return fmt.Errorf("batchNodeCount must be >= 0 and batchPercentage must be between 0.0 and 1.0 for node pool [%s] in cluster [%s (id: %s)]", npName, clusterName, config.Name)
+		}
+	default:
+		return fmt.Errorf("invalid upgrade strategy %q for node pool [%s] in cluster [%s (id: %s)], must be %s or %s", settings.Strategy, npName, clusterName, config.Name, UpgradeStrategySurge, UpgradeStrategyBlueGreen)
+	}
+	return nil
+}
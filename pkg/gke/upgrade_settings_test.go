@@ -0,0 +1,84 @@
+package gke
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	gkeapi "google.golang.org/api/container/v1"
+)
+
+var _ = Describe("UpgradeSettings", func() {
+	var (
+		nodePoolName = "test-node-pool"
+		config       = &gkev1.GKEClusterConfig{
+			Spec: gkev1.GKEClusterConfigSpec{
+				ClusterName: "test-cluster",
+			},
+		}
+	)
+
+	It("should build the upgrade settings", func() {
+		Expect(BuildUpgradeSettings(nil)).To(BeNil())
+		Expect(BuildUpgradeSettings(&gkeapi.UpgradeSettings{
+			Strategy:       UpgradeStrategySurge,
+			MaxSurge:       0,
+			MaxUnavailable: 1,
+		})).To(Equal(&gkev1.GKENodePoolUpgradeSettings{
+			Strategy:       UpgradeStrategySurge,
+			MaxSurge:       0,
+			MaxUnavailable: 1,
+		}))
+	})
+
+	It("should compare upgrade settings", func() {
+		settings := &gkev1.GKENodePoolUpgradeSettings{MaxSurge: 1}
+		Expect(upgradeSettingsEqual(settings, &gkev1.GKENodePoolUpgradeSettings{Strategy: UpgradeStrategySurge, MaxSurge: 1})).To(BeTrue())
+		Expect(upgradeSettingsEqual(settings, &gkev1.GKENodePoolUpgradeSettings{Strategy: UpgradeStrategySurge, MaxSurge: 2})).To(BeFalse())
+		Expect(upgradeSettingsEqual(settings, nil)).To(BeFalse())
+
+		settings = &gkev1.GKENodePoolUpgradeSettings{Strategy: UpgradeStrategyBlueGreen}
+		Expect(upgradeSettingsEqual(settings, &gkev1.GKENodePoolUpgradeSettings{Strategy: UpgradeStrategySurge})).To(BeFalse())
+		Expect(upgradeSettingsEqual(settings, &gkev1.GKENodePoolUpgradeSettings{
+			Strategy: UpgradeStrategyBlueGreen,
+			BlueGreenSettings: &gkev1.GKEBlueGreenSettings{
+				NodePoolSoakDuration: "3600s",
+			},
+		})).To(BeTrue())
+	})
+
+	It("should validate the upgrade settings", func() {
+		np := &gkev1.GKENodePoolConfig{
+			Name: &nodePoolName,
+			UpgradeSettings: &gkev1.GKENodePoolUpgradeSettings{
+				Strategy: "ROLLING",
+			},
+		}
+		Expect(validateUpgradeSettings(np, config)).To(MatchError(`invalid upgrade strategy "ROLLING" for node pool [test-node-pool] in cluster [test-cluster (id: )], must be SURGE or BLUE_GREEN`))
+
+		np.UpgradeSettings = &gkev1.GKENodePoolUpgradeSettings{
+			MaxSurge:          1,
+			BlueGreenSettings: &gkev1.GKEBlueGreenSettings{},
+		}
+		Expect(validateUpgradeSettings(np, config)).To(MatchError("blueGreenSettings can only be set with the BLUE_GREEN upgrade strategy for node pool [test-node-pool] in cluster [test-cluster (id: )]"))
+
+		np.UpgradeSettings = &gkev1.GKENodePoolUpgradeSettings{
+			MaxSurge:       -1,
+			MaxUnavailable: 1,
+		}
+		Expect(validateUpgradeSettings(np, config)).To(HaveOccurred())
+
+		np.UpgradeSettings = &gkev1.GKENodePoolUpgradeSettings{
+			Strategy: UpgradeStrategyBlueGreen,
+			BlueGreenSettings: &gkev1.GKEBlueGreenSettings{
+				StandardRolloutPolicy: &gkev1.GKEStandardRolloutPolicy{
+					BatchPercentage: 1.5,
+				},
+			},
+		}
+		Expect(validateUpgradeSettings(np, config)).To(HaveOccurred())
+
+		np.UpgradeSettings.BlueGreenSettings.StandardRolloutPolicy.BatchPercentage = 0.5
+		Expect(validateUpgradeSettings(np, config)).To(Succeed())
+	})
+})
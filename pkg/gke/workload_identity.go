@@ -0,0 +1,94 @@
+package gke
+
+import (
+	"fmt"
+
+	gkeapi "google.golang.org/api/container/v1"
+
+	gkev1 "github.com/rancher/gke-operator/pkg/apis/gke.cattle.io/v1"
+	"github.com/rancher/gke-operator/pkg/utils"
+)
+
+// Workload Metadata Modes
+const (
+	// WorkloadMetadataModeGKE exposes the GKE metadata server to the workloads, required by
+	// workload identity
+	WorkloadMetadataModeGKE = "GKE_METADATA"
+	// WorkloadMetadataModeGCE exposes the Compute Engine metadata server to the workloads
+	WorkloadMetadataModeGCE = "GCE_METADATA"
+)
+
+// BuildWorkloadIdentityConfig returns the spec representation of the given GKE workload identity
+// configuration.
+func BuildWorkloadIdentityConfig(workloadIdentityConfig *gkeapi.WorkloadIdentityConfig) *gkev1.GKEWorkloadIdentityConfig {
+	if workloadIdentityConfig == nil {
+		return &gkev1.GKEWorkloadIdentityConfig{}
+	}
+	return &gkev1.GKEWorkloadIdentityConfig{
+		WorkloadPool: workloadIdentityConfig.WorkloadPool,
+	}
+}
+
+// BuildWorkloadMetadataConfig returns the spec representation of the given GKE workload metadata
+// configuration of a node pool.
+func BuildWorkloadMetadataConfig(workloadMetadataConfig *gkeapi.WorkloadMetadataConfig) *gkev1.GKEWorkloadMetadataConfig {
+	if workloadMetadataConfig == nil {
+		return nil
+	}
+	return &gkev1.GKEWorkloadMetadataConfig{
+		Mode: workloadMetadataConfig.Mode,
+	}
+}
+
+// workloadMetadataMode returns the workload metadata mode of the node pool, or an empty string if
+// it is not set.
+func workloadMetadataMode(np *gkev1.GKENodePoolConfig) string {
+	if np.Config == nil || np.Config.WorkloadMetadataConfig == nil {
+		return ""
+	}
+	return np.Config.WorkloadMetadataConfig.Mode
+}
+
+// desiredWorkloadMetadataMode returns the workload metadata mode the node pool should use, or an
+// empty string if it is left as is. A mode set on the node pool takes precedence, otherwise the node
+// pool follows the workload identity configuration of the cluster.
+func desiredWorkloadMetadataMode(nodePool, upstreamNodePool *gkev1.GKENodePoolConfig, config *gkev1.GKEClusterConfig) string {
+	if mode := workloadMetadataMode(nodePool); mode != "" {
+		return mode
+	}
+	workloadIdentityConfig := config.Spec.WorkloadIdentityConfig
+	switch {
+	case workloadIdentityConfig == nil:
+		return ""
+	case workloadIdentityConfig.WorkloadPool != "":
+		return WorkloadMetadataModeGKE
+	case workloadMetadataMode(upstreamNodePool) == WorkloadMetadataModeGKE:
+		return WorkloadMetadataModeGCE
+	}
+	return ""
+}
+
+func validateWorkloadIdentity(config *gkev1.GKEClusterConfig) error {
+	workloadIdentityConfig := config.Spec.WorkloadIdentityConfig
+	if workloadIdentityConfig == nil {
+		return nil
+	}
+	clusterName := config.Spec.ClusterName
+	workloadPool := workloadIdentityConfig.WorkloadPool
+	if workloadPool == "" {
+		if IsAutopilot(config) {
+			return fmt.Errorf("workload identity cannot be disabled for autopilot cluster [%s (id: %s)]", clusterName, config.Name)
+		}
+		for i := range config.Spec.NodePools {
+			np := &config.Spec.NodePools[i]
+			if workloadMetadataMode(np) == WorkloadMetadataModeGKE {
+				return fmt.Errorf("workload metadata mode %s of node pool [%s] requires workload identity to be enabled for cluster [%s (id: %s)]", WorkloadMetadataModeGKE, utils.StringValue(np.Name), clusterName, config.Name)
+			}
+		}
+		return nil
+	}
+	if expected := config.Spec.ProjectID + ".svc.id.goog"; workloadPool != expected {
+		return fmt.Errorf("invalid workload pool %q for cluster [%s (id: %s)], must be %s", workloadPool, clusterName, config.Name, expected)
+	}
+	return nil
+}